@@ -0,0 +1,234 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNegativeCycle is returned by all-pairs algorithms
+// when the graph contains a cycle of negative total weight.
+var ErrNegativeCycle = errors.New("graph: negative cycle")
+
+// AllPairs holds the shortest paths between all pairs of vertices
+// in a graph, as computed by FloydWarshall or Johnson.
+type AllPairs struct {
+	// dist[u][v] is the length of a shortest path from u to v,
+	// or +Inf if there is no such path.
+	dist [][]float64
+
+	// next[u][v] is the vertex following u on a shortest path
+	// from u to v, or -1 if there is no such path.
+	next [][]int
+}
+
+// newAllPairs returns a table for n vertices with no paths.
+func newAllPairs(n int) *AllPairs {
+	p := &AllPairs{dist: make([][]float64, n), next: make([][]int, n)}
+	for u := range p.dist {
+		p.dist[u] = make([]float64, n)
+		p.next[u] = make([]int, n)
+		for v := range p.dist[u] {
+			p.dist[u][v] = math.Inf(1)
+			p.next[u][v] = -1
+		}
+	}
+	return p
+}
+
+// Dist returns the length of a shortest path from u to v,
+// or +Inf if v is not reachable from u. Time complexity: O(1).
+func (p *AllPairs) Dist(u, v int) float64 {
+	return p.dist[u][v]
+}
+
+// Path returns the vertices on a shortest path from u to v,
+// starting with u and ending with v, or nil if v is not reachable from u.
+// Time complexity: O(k), where k is the number of vertices on the path.
+func (p *AllPairs) Path(u, v int) []int {
+	if p.next[u][v] == -1 {
+		return nil
+	}
+	path := []int{u}
+	for u != v {
+		u = p.next[u][v]
+		path = append(path, u)
+	}
+	return path
+}
+
+// FloydWarshall computes the shortest paths between all pairs of vertices
+// in g, working directly on the adjacency matrix.
+// The weight of an edge is computed by calling weight with its label.
+// If g contains a negative cycle, ErrNegativeCycle is returned.
+// Time complexity: O(n*n*n), where n is the number of vertices.
+func FloydWarshall(g *Matrix, weight func(x interface{}) float64) (*AllPairs, error) {
+	return FloydWarshallContext(context.Background(), g, weight)
+}
+
+// FloydWarshallContext is like FloydWarshall, but stops with ctx.Err()
+// if ctx is cancelled before the computation is done.
+func FloydWarshallContext(ctx context.Context, g *Matrix, weight func(x interface{}) float64) (*AllPairs, error) {
+	n := g.NumVertices()
+	p := newAllPairs(n)
+	for u, row := range g.adj {
+		for v, x := range row {
+			if x != noEdge {
+				p.dist[u][v] = weight(x)
+				p.next[u][v] = v
+			}
+		}
+		if !(p.dist[u][u] < 0) { // a negative loop is a negative cycle
+			p.dist[u][u] = 0
+			p.next[u][u] = u
+		}
+	}
+
+	for k := 0; k < n; k++ {
+		// Each round takes Θ(n*n) time, so check for cancellation every round.
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		dk := p.dist[k]
+		for i := 0; i < n; i++ {
+			di, ni := p.dist[i], p.next[i]
+			dik := di[k]
+			if math.IsInf(dik, 1) {
+				continue
+			}
+			for j, dkj := range dk {
+				if alt := dik + dkj; alt < di[j] {
+					di[j] = alt
+					ni[j] = ni[k]
+				}
+			}
+		}
+	}
+
+	for v := 0; v < n; v++ {
+		if p.dist[v][v] < 0 {
+			return nil, ErrNegativeCycle
+		}
+	}
+	return p, nil
+}
+
+// Johnson computes the shortest paths between all pairs of vertices in g.
+// The edges are reweighted using Bellman-Ford to become non-negative,
+// after which Dijkstra is run from every vertex; this is well suited
+// for sparse graphs such as graph.Hash.
+// The weight of an edge is computed by calling weight with its label.
+// If g contains a negative cycle, an error wrapping ErrNegativeCycle
+// is returned.
+// Time complexity: O(n*m*log n) for graph.Hash,
+// where n and m are the number of vertices and edges.
+func Johnson(g Iterator, weight func(x interface{}) float64) (*AllPairs, error) {
+	return JohnsonContext(context.Background(), g, weight)
+}
+
+// JohnsonContext is like Johnson, but stops with ctx.Err()
+// if ctx is cancelled before the computation is done.
+func JohnsonContext(ctx context.Context, g Iterator, weight func(x interface{}) float64) (*AllPairs, error) {
+	n := g.NumVertices()
+
+	// Compute the potential h[v] as the shortest distance
+	// to v from an extra vertex n that has an edge to every vertex.
+	h, _, cycle, err := BellmanFordContext(ctx, &augmented{g, weight}, n, floatWeight)
+	if err != nil {
+		return nil, err
+	}
+	if cycle != nil {
+		return nil, fmt.Errorf("%w: %v", ErrNegativeCycle, cycle)
+	}
+
+	p := newAllPairs(n)
+	r := &reweighted{g, weight, h}
+	for u := 0; u < n; u++ {
+		dist, parent, err := DijkstraContext(ctx, r, u, floatWeight)
+		if err != nil {
+			return nil, err
+		}
+		for v, d := range dist {
+			if !math.IsInf(d, 1) {
+				p.dist[u][v] = d - h[u] + h[v]
+			}
+		}
+		firstHops(parent, u, p.next[u])
+	}
+	return p, nil
+}
+
+// floatWeight is the weight function for float64 labels.
+func floatWeight(x interface{}) float64 { return x.(float64) }
+
+// augmented is g with an extra vertex n that has an edge
+// of weight 0 to every other vertex. Labels are converted to weights.
+type augmented struct {
+	g      Iterator
+	weight func(x interface{}) float64
+}
+
+func (a *augmented) NumVertices() int { return a.g.NumVertices() + 1 }
+
+func (a *augmented) DoNeighbors(v int, action func(w int, x interface{})) {
+	n := a.g.NumVertices()
+	if v == n {
+		for w := 0; w < n; w++ {
+			action(w, 0.0)
+		}
+		return
+	}
+	a.g.DoNeighbors(v, func(w int, x interface{}) {
+		action(w, a.weight(x))
+	})
+}
+
+// reweighted is g with the weight of each edge (v, w)
+// changed to weight + h[v] - h[w], which is non-negative
+// if h holds shortest distances from some common vertex.
+type reweighted struct {
+	g      Iterator
+	weight func(x interface{}) float64
+	h      []float64
+}
+
+func (r *reweighted) NumVertices() int { return r.g.NumVertices() }
+
+func (r *reweighted) DoNeighbors(v int, action func(w int, x interface{})) {
+	r.g.DoNeighbors(v, func(w int, x interface{}) {
+		// Clamp rounding errors that would otherwise give tiny negative weights.
+		action(w, math.Max(0, r.weight(x)+r.h[v]-r.h[w]))
+	})
+}
+
+// firstHops sets hop[v] to the vertex following u on the path
+// from u to v in the parent array, or -1 if there is no such path.
+func firstHops(parent []int, u int, hop []int) {
+	for v := range hop {
+		hop[v] = -1
+	}
+	hop[u] = u
+	var stack []int
+	for v := range hop {
+		// Walk towards u until a vertex with a known hop is found.
+		stack = stack[:0]
+		w := v
+		for hop[w] == -1 && parent[w] != -1 {
+			stack = append(stack, w)
+			w = parent[w]
+		}
+		var first int
+		switch {
+		case w == u && len(stack) > 0:
+			first = stack[len(stack)-1]
+		case w != u && hop[w] != -1:
+			first = hop[w]
+		default:
+			continue
+		}
+		for _, x := range stack {
+			hop[x] = first
+		}
+	}
+}
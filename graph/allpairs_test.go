@@ -0,0 +1,75 @@
+package graph_test
+
+import (
+	. "."
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAllPairs(t *testing.T) {
+	impls := map[string]func(n int, edges [][3]int) (*AllPairs, error){
+		"FloydWarshall": func(n int, edges [][3]int) (*AllPairs, error) {
+			g := NewMatrix(n)
+			for _, e := range edges {
+				g.AddLabel(e[0], e[1], e[2])
+			}
+			return FloydWarshall(g, intWeight)
+		},
+		"Johnson": func(n int, edges [][3]int) (*AllPairs, error) {
+			g := NewHash(n)
+			for _, e := range edges {
+				g.AddLabel(e[0], e[1], e[2])
+			}
+			return Johnson(g, intWeight)
+		},
+	}
+	edges := [][3]int{
+		{0, 1, 3}, {0, 2, 8}, {0, 4, -4},
+		{1, 3, 1}, {1, 4, 7},
+		{2, 1, 4},
+		{3, 0, 2}, {3, 2, -5},
+		{4, 3, 6},
+	}
+	exp := [][]float64{
+		{0, 1, -3, 2, -4},
+		{3, 0, -4, 1, -1},
+		{7, 4, 0, 5, 3},
+		{2, -1, -5, 0, -2},
+		{8, 5, 1, 6, 0},
+	}
+
+	for impl, f := range impls {
+		p, err := f(6, edges)
+		if err != nil {
+			t.Fatalf("%s: error %v", impl, err)
+		}
+		for u, row := range exp {
+			for v, d := range row {
+				if mess, diff := diff(p.Dist(u, v), d); diff {
+					t.Errorf("%s: Dist(%d, %d) %s", impl, u, v, mess)
+				}
+			}
+		}
+		if mess, diff := diff(p.Path(0, 2), []int{0, 4, 3, 2}); diff {
+			t.Errorf("%s: Path(0, 2) %s", impl, mess)
+		}
+		if mess, diff := diff(p.Path(2, 0), []int{2, 1, 3, 0}); diff {
+			t.Errorf("%s: Path(2, 0) %s", impl, mess)
+		}
+		if mess, diff := diff(p.Path(3, 3), []int{3}); diff {
+			t.Errorf("%s: Path(3, 3) %s", impl, mess)
+		}
+		if mess, diff := diff(math.IsInf(p.Dist(0, 5), 1), true); diff {
+			t.Errorf("%s: Dist(0, 5) is +Inf %s", impl, mess)
+		}
+		if p.Path(5, 0) != nil {
+			t.Errorf("%s: Path(5, 0) %v; want nil", impl, p.Path(5, 0))
+		}
+
+		_, err = f(3, [][3]int{{0, 1, 1}, {1, 2, -2}, {2, 1, 1}})
+		if !errors.Is(err, ErrNegativeCycle) {
+			t.Errorf("%s: error %v; want ErrNegativeCycle", impl, err)
+		}
+	}
+}
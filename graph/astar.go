@@ -0,0 +1,85 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// SearchStats holds statistics collected during a search.
+type SearchStats struct {
+	// Expanded is the number of times a vertex was removed from
+	// the queue and had its neighbors examined.
+	Expanded int
+
+	// Discovered is the number of distinct vertices reached.
+	Discovered int
+}
+
+// AStar finds a shortest path from src to dst in g using A* search.
+// The weight of an edge is computed by calling weight with the label
+// of the edge; weights must be non-negative. The heuristic h(v) estimates
+// the distance from v to dst; the returned path is a shortest path if h
+// never overestimates. With h(v) = 0 the search is equivalent to Dijkstra.
+//
+// AStar returns the vertices on the path, starting with src and ending
+// with dst, and its cost. If dst is not reachable from src, path is nil
+// and cost is +Inf. If a negative weight is found, the search stops and
+// an error wrapping ErrNegativeWeight is returned.
+//
+// Time complexity: O((n+m)log n) for graph.Hash in the worst case,
+// but usually much less with a good heuristic.
+func AStar(g Iterator, src, dst int, weight func(x interface{}) float64, h func(v int) float64) (path []int, cost float64, stats SearchStats, err error) {
+	return AStarContext(context.Background(), g, src, dst, weight, h)
+}
+
+// AStarContext is like AStar, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func AStarContext(ctx context.Context, g Iterator, src, dst int, weight func(x interface{}) float64, h func(v int) float64) (path []int, cost float64, stats SearchStats, err error) {
+	n := g.NumVertices()
+	dist := make([]float64, n)
+	parent := make([]int, n)
+	for v := range dist {
+		dist[v] = math.Inf(1)
+		parent[v] = -1
+	}
+
+	dist[src] = 0
+	stats.Discovered = 1
+	c := &canceller{ctx: ctx}
+	q := &pqueue{}
+	q.push(src, h(src))
+	for q.Len() > 0 && err == nil {
+		if err = c.step(); err != nil {
+			break
+		}
+		v, f := q.pop()
+		if f > dist[v]+h(v) {
+			continue // stale queue entry
+		}
+		if v == dst {
+			return Path(parent, src, dst), dist[dst], stats, nil
+		}
+		stats.Expanded++
+		d := dist[v]
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			if err != nil {
+				return
+			}
+			c := weight(x)
+			if c < 0 {
+				err = fmt.Errorf("%w: edge (%d, %d) has weight %v", ErrNegativeWeight, v, w, c)
+				return
+			}
+			if alt := d + c; alt < dist[w] {
+				if math.IsInf(dist[w], 1) {
+					stats.Discovered++
+				}
+				dist[w] = alt
+				parent[w] = v
+				q.push(w, alt+h(w))
+			}
+		})
+	}
+	return nil, math.Inf(1), stats, err
+}
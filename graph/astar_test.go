@@ -0,0 +1,70 @@
+package graph_test
+
+import (
+	. "."
+	"math"
+	"testing"
+)
+
+// Returns a size×size grid with unit weights between adjacent cells.
+// Cell (x, y) is vertex y*size + x.
+func grid(f func(int) Grapher, size int) Grapher {
+	g := f(size * size)
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			v := y*size + x
+			if x+1 < size {
+				g.AddBiLabel(v, v+1, 1)
+			}
+			if y+1 < size {
+				g.AddBiLabel(v, v+size, 1)
+			}
+		}
+	}
+	return g
+}
+
+func TestAStar(t *testing.T) {
+	const size = 10
+	// The destination is in the same row as the source, so that cells
+	// off that row have a larger f-value and need not be expanded.
+	dst := size - 1
+	manhattan := func(v int) float64 {
+		return float64(size-1-v%size) + float64(v/size)
+	}
+	zero := func(int) float64 { return 0 }
+
+	for impl, f := range AlgoFuncs {
+		g := grid(f, size)
+
+		path, cost, stats, err := AStar(g, 0, dst, intWeight, manhattan)
+		if err != nil {
+			t.Fatalf("%s: AStar error %v", impl, err)
+		}
+		if mess, diff := diff(cost, float64(size-1)); diff {
+			t.Errorf("%s: cost %s", impl, mess)
+		}
+		if mess, diff := diff(len(path), size); diff {
+			t.Errorf("%s: len(path) %s", impl, mess)
+		}
+		if path[0] != 0 || path[len(path)-1] != dst {
+			t.Errorf("%s: path %v; want from 0 to %d", impl, path, dst)
+		}
+
+		_, cost0, stats0, _ := AStar(g, 0, dst, intWeight, zero)
+		if mess, diff := diff(cost0, cost); diff {
+			t.Errorf("%s: cost with zero heuristic %s", impl, mess)
+		}
+		if stats.Expanded >= stats0.Expanded {
+			t.Errorf("%s: expanded %d with heuristic, %d without; want fewer",
+				impl, stats.Expanded, stats0.Expanded)
+		}
+
+		g.RemoveBi(dst-1, dst)
+		g.RemoveBi(dst+size, dst)
+		path, cost, _, _ = AStar(g, 0, dst, intWeight, manhattan)
+		if path != nil || !math.IsInf(cost, 1) {
+			t.Errorf("%s: unreachable path %v cost %v; want nil +Inf", impl, path, cost)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package graph
+
+import (
+	"context"
+	"math"
+)
+
+// BellmanFord computes the shortest paths in g from s to all other vertices.
+// The weight of an edge is computed by calling weight with the label
+// of the edge; weights may be negative.
+//
+// dist and parent are as for Dijkstra. If a cycle of negative total
+// weight is reachable from s, shortest paths are not well defined;
+// in that case cycle contains the vertices of one such cycle in order,
+// i.e. there is an edge from cycle[i] to cycle[i+1] and from the last
+// vertex back to cycle[0]. Otherwise cycle is nil.
+//
+// Time complexity: O(n*m) for graph.Hash and O(n*n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func BellmanFord(g Iterator, s int, weight func(x interface{}) float64) (dist []float64, parent []int, cycle []int) {
+	dist, parent, cycle, _ = BellmanFordContext(context.Background(), g, s, weight)
+	return
+}
+
+// BellmanFordContext is like BellmanFord, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func BellmanFordContext(ctx context.Context, g Iterator, s int, weight func(x interface{}) float64) (dist []float64, parent []int, cycle []int, err error) {
+	n := g.NumVertices()
+	dist = make([]float64, n)
+	parent = make([]int, n)
+	for v := range dist {
+		dist[v] = math.Inf(1)
+		parent[v] = -1
+	}
+	dist[s] = 0
+
+	// After round i, all shortest paths with at most i edges are found.
+	// A relaxation in round n proves that there is a negative cycle.
+	c := &canceller{ctx: ctx}
+	last := -1
+	for i := 1; i <= n; i++ {
+		last = -1
+		for v := 0; v < n; v++ {
+			if err = c.step(); err != nil {
+				return
+			}
+			if math.IsInf(dist[v], 1) {
+				continue
+			}
+			g.DoNeighbors(v, func(w int, x interface{}) {
+				if alt := dist[v] + weight(x); alt < dist[w] {
+					dist[w] = alt
+					parent[w] = v
+					last = w
+				}
+			})
+		}
+		if last == -1 {
+			return
+		}
+	}
+	return dist, parent, negativeCycle(parent, last), nil
+}
+
+// negativeCycle returns the cycle in the parent array
+// reached by following parent pointers from v.
+func negativeCycle(parent []int, v int) []int {
+	// Walking n steps back is guaranteed to end up on the cycle.
+	for i := 0; i < len(parent); i++ {
+		v = parent[v]
+	}
+	cycle := []int{v}
+	for w := parent[v]; w != v; w = parent[w] {
+		cycle = append(cycle, w)
+	}
+	// The cycle was collected backwards; reverse it to follow edge direction.
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
@@ -0,0 +1,60 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestBellmanFord(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(5)
+		g.AddLabel(0, 1, 6)
+		g.AddLabel(0, 3, 7)
+		g.AddLabel(1, 2, 5)
+		g.AddLabel(1, 3, 8)
+		g.AddLabel(1, 4, -4)
+		g.AddLabel(2, 1, -2)
+		g.AddLabel(3, 2, -3)
+		g.AddLabel(3, 4, 9)
+		g.AddLabel(4, 2, 7)
+
+		dist, parent, cycle := BellmanFord(g, 0, intWeight)
+		if cycle != nil {
+			t.Fatalf("%s: BellmanFord cycle %v; want nil", impl, cycle)
+		}
+		exp := []float64{0, 2, 4, 7, -2}
+		for v, d := range exp {
+			if mess, diff := diff(dist[v], d); diff {
+				t.Errorf("%s: dist[%d] %s", impl, v, mess)
+			}
+		}
+		if mess, diff := diff(Path(parent, 0, 4), []int{0, 3, 2, 1, 4}); diff {
+			t.Errorf("%s: Path(parent, 0, 4) %s", impl, mess)
+		}
+
+		// 1 -> 4 -> 2 -> 1 now has weight -1.
+		g.AddLabel(4, 2, 5)
+		_, _, cycle = BellmanFord(g, 0, intWeight)
+		if mess, diff := diff(len(cycle), 3); diff {
+			t.Fatalf("%s: len(cycle) %s", impl, mess)
+		}
+		sum := 0
+		for i, v := range cycle {
+			w := cycle[(i+1)%len(cycle)]
+			if !g.HasEdge(v, w) {
+				t.Fatalf("%s: cycle %v has no edge (%d, %d)", impl, cycle, v, w)
+			}
+			sum += g.Label(v, w).(int)
+		}
+		if sum >= 0 {
+			t.Errorf("%s: cycle %v has weight %d; want negative", impl, cycle, sum)
+		}
+
+		// The cycle is not reachable from 3 without the edges leaving 3.
+		g.Remove(3, 2)
+		g.Remove(3, 4)
+		if _, _, cycle = BellmanFord(g, 3, intWeight); cycle != nil {
+			t.Errorf("%s: BellmanFord from 3 cycle %v; want nil", impl, cycle)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package graph
+
+import "context"
+
+// BFSResult is a breadth-first search tree, or forest if there are
+// several sources, holding the number of edges on a shortest path
+// from the nearest source to each vertex.
+type BFSResult struct {
+	dist   []int // dist[v] is the hop distance to v, or -1 if unreachable
+	parent []int // parent[v] is the vertex from which v was reached, or -1
+	source []int // source[v] is the source nearest to v, or -1
+	order  []int // the reachable vertices in order of increasing distance
+}
+
+// BFSTree performs a breadth-first search of g starting at all the
+// sources at once, so that each vertex is reached from the nearest source.
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func BFSTree(g Iterator, sources []int) *BFSResult {
+	t, _ := BFSTreeContext(context.Background(), g, sources)
+	return t
+}
+
+// BFSTreeContext is like BFSTree, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func BFSTreeContext(ctx context.Context, g Iterator, sources []int) (*BFSResult, error) {
+	n := g.NumVertices()
+	t := &BFSResult{
+		dist:   make([]int, n),
+		parent: make([]int, n),
+		source: make([]int, n),
+	}
+	for v := range t.dist {
+		t.dist[v] = -1
+		t.parent[v] = -1
+		t.source[v] = -1
+	}
+	visited := make([]bool, n)
+	c := &canceller{ctx: ctx}
+	traverse(g, sources, visited, func(w, p int) bool {
+		if c.step() != nil {
+			return true
+		}
+		if p == -1 {
+			t.dist[w] = 0
+			t.source[w] = w
+		} else {
+			t.dist[w] = t.dist[p] + 1
+			t.parent[w] = p
+			t.source[w] = t.source[p]
+		}
+		t.order = append(t.order, w)
+		return false
+	}, bfs)
+	if c.err != nil {
+		return nil, c.err
+	}
+	return t, nil
+}
+
+// Dist returns the number of edges on a shortest path from the nearest
+// source to v, or -1 if v is not reachable. Time complexity: O(1).
+func (t *BFSResult) Dist(v int) int {
+	return t.dist[v]
+}
+
+// Parent returns the vertex preceding v on a shortest path from the
+// nearest source, or -1 if v is a source or not reachable.
+// Time complexity: O(1).
+func (t *BFSResult) Parent(v int) int {
+	return t.parent[v]
+}
+
+// Source returns the source nearest to v, or -1 if v is not reachable.
+// Time complexity: O(1).
+func (t *BFSResult) Source(v int) int {
+	return t.source[v]
+}
+
+// PathTo returns the vertices on a shortest path from the nearest source
+// to v, starting with the source and ending with v,
+// or nil if v is not reachable.
+// Time complexity: O(k), where k is the number of vertices on the path.
+func (t *BFSResult) PathTo(v int) []int {
+	if t.dist[v] == -1 {
+		return nil
+	}
+	return Path(t.parent, t.source[v], v)
+}
+
+// Levels returns the reachable vertices grouped by distance:
+// Levels()[d] lists the vertices at distance d in the order
+// in which they were visited.
+// Time complexity: O(n), where n is the number of vertices.
+func (t *BFSResult) Levels() [][]int {
+	var levels [][]int
+	for _, v := range t.order {
+		d := t.dist[v]
+		if d == len(levels) {
+			levels = append(levels, nil)
+		}
+		levels[d] = append(levels[d], v)
+	}
+	return levels
+}
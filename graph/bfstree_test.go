@@ -0,0 +1,61 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestBFSTree(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		// A path 0 - 1 - 2 - 3 - 4 - 5 - 6 and an isolated vertex 7.
+		g := f(8)
+		for v := 0; v+1 < 7; v++ {
+			g.AddBi(v, v+1)
+		}
+
+		tree := BFSTree(g, []int{0})
+		if mess, diff := diff(tree.Dist(6), 6); diff {
+			t.Errorf("%s: Dist(6) %s", impl, mess)
+		}
+		if mess, diff := diff(tree.Dist(7), -1); diff {
+			t.Errorf("%s: Dist(7) %s", impl, mess)
+		}
+		if mess, diff := diff(tree.Parent(3), 2); diff {
+			t.Errorf("%s: Parent(3) %s", impl, mess)
+		}
+		if mess, diff := diff(tree.Parent(0), -1); diff {
+			t.Errorf("%s: Parent(0) %s", impl, mess)
+		}
+		if mess, diff := diff(tree.PathTo(3), []int{0, 1, 2, 3}); diff {
+			t.Errorf("%s: PathTo(3) %s", impl, mess)
+		}
+		if tree.PathTo(7) != nil {
+			t.Errorf("%s: PathTo(7) %v; want nil", impl, tree.PathTo(7))
+		}
+
+		// Nearest of two facilities.
+		tree = BFSTree(g, []int{1, 5})
+		if mess, diff := diff(tree.Dist(3), 2); diff {
+			t.Errorf("%s: Dist(3) %s", impl, mess)
+		}
+		if mess, diff := diff(tree.Source(6), 5); diff {
+			t.Errorf("%s: Source(6) %s", impl, mess)
+		}
+		if mess, diff := diff(tree.PathTo(2), []int{1, 2}); diff {
+			t.Errorf("%s: PathTo(2) %s", impl, mess)
+		}
+		levels := tree.Levels()
+		if mess, diff := diff(len(levels), 3); diff {
+			t.Fatalf("%s: len(Levels()) %s", impl, mess)
+		}
+		for d, exp := range []string{"15", "0246", "3"} {
+			res := ""
+			for _, v := range levels[d] {
+				res += string(rune('0' + v))
+			}
+			if mess, diff := diffPerm(res, exp); diff {
+				t.Errorf("%s: Levels()[%d] %s", impl, d, mess)
+			}
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package graph
+
+import "context"
+
+// BidirectionalBFS returns a path from src to dst in g with as few edges
+// as possible, starting with src and ending with dst, or nil if there is
+// no such path. It searches forward from src in g and backward from dst
+// in rev, one level at a time and always on the side with the smaller
+// frontier, until the two searches meet.
+//
+// rev must list the predecessors of each vertex, i.e. have an edge from
+// w to v for each edge from v to w in g. For a directed graph, use the
+// result of Transpose or Reversed; for an undirected graph, rev may be g itself.
+//
+// Time complexity: O(n+m) in the worst case, where n and m are the number
+// of vertices and edges, but typically far less than a one-sided search
+// for graphs with a large branching factor.
+func BidirectionalBFS(g, rev Iterator, src, dst int) []int {
+	path, _ := BidirectionalBFSContext(context.Background(), g, rev, src, dst)
+	return path
+}
+
+// BidirectionalBFSContext is like BidirectionalBFS, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func BidirectionalBFSContext(ctx context.Context, g, rev Iterator, src, dst int) ([]int, error) {
+	if src == dst {
+		return []int{src}, nil
+	}
+	n := g.NumVertices()
+	c := &canceller{ctx: ctx}
+	fwd := newHalfSearch(n, src)
+	bwd := newHalfSearch(n, dst)
+	for len(fwd.frontier) > 0 && len(bwd.frontier) > 0 {
+		var meet int
+		var err error
+		if len(fwd.frontier) <= len(bwd.frontier) {
+			meet, err = fwd.expand(c, g, bwd)
+		} else {
+			meet, err = bwd.expand(c, rev, fwd)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if meet == -1 {
+			continue
+		}
+		path := Path(fwd.parent, src, meet)
+		for v := bwd.parent[meet]; v != -1; v = bwd.parent[v] {
+			path = append(path, v)
+		}
+		return path, nil
+	}
+	return nil, nil
+}
+
+// halfSearch is one side of a bidirectional search.
+type halfSearch struct {
+	dist     []int // dist[v] is the distance from the root, or -1
+	parent   []int // parent[v] is the vertex from which v was reached, or -1
+	frontier []int // the vertices at the largest distance found so far
+}
+
+func newHalfSearch(n, root int) *halfSearch {
+	h := &halfSearch{dist: make([]int, n), parent: make([]int, n), frontier: []int{root}}
+	for v := range h.dist {
+		h.dist[v] = -1
+		h.parent[v] = -1
+	}
+	h.dist[root] = 0
+	return h
+}
+
+// expand visits the next level of the search in g. It returns the vertex
+// minimizing the total distance among the new vertices that have already
+// been reached by the other search, or -1 if there is no such vertex.
+// Since a whole level is expanded, this gives a shortest path.
+// It stops with an error if the context of c is cancelled.
+func (h *halfSearch) expand(c *canceller, g Iterator, other *halfSearch) (meet int, err error) {
+	meet = -1
+	best := 0
+	var next []int
+	for _, v := range h.frontier {
+		if err = c.step(); err != nil {
+			return -1, err
+		}
+		g.DoNeighbors(v, func(w int, _ interface{}) {
+			if h.dist[w] != -1 {
+				return
+			}
+			h.dist[w] = h.dist[v] + 1
+			h.parent[w] = v
+			next = append(next, w)
+			if d := other.dist[w]; d != -1 && (meet == -1 || h.dist[w]+d < best) {
+				meet, best = w, h.dist[w]+d
+			}
+		})
+	}
+	h.frontier = next
+	return
+}
@@ -0,0 +1,79 @@
+package graph_test
+
+import (
+	. "."
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+// Constructs a graph with n vertices and m random directed edges,
+// like setupGraphs in main.go but with a fixed seed.
+func randomGraph(f func(int) Grapher, n, m int, seed int64) Grapher {
+	g := f(n)
+	random := rand.New(rand.NewSource(seed))
+	for g.NumEdges() < m {
+		g.Add(random.Intn(n), random.Intn(n))
+	}
+	return g
+}
+
+func TestBidirectionalBFS(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := randomGraph(f, 200, 400, 1)
+		rev := Transpose(g)
+		for src := 0; src < g.NumVertices(); src += 7 {
+			tree := BFSTree(g, []int{src})
+			for dst := 0; dst < g.NumVertices(); dst++ {
+				path := BidirectionalBFS(g, rev, src, dst)
+				if tree.Dist(dst) == -1 {
+					if path != nil {
+						t.Errorf("%s: BidirectionalBFS(%d, %d) %v; want nil", impl, src, dst, path)
+					}
+					continue
+				}
+				if len(path)-1 != tree.Dist(dst) {
+					t.Fatalf("%s: BidirectionalBFS(%d, %d) %v; want %d edges",
+						impl, src, dst, path, tree.Dist(dst))
+				}
+				if path[0] != src || path[len(path)-1] != dst {
+					t.Fatalf("%s: BidirectionalBFS(%d, %d) %v; wrong ends", impl, src, dst, path)
+				}
+				for i := 0; i+1 < len(path); i++ {
+					if !g.HasEdge(path[i], path[i+1]) {
+						t.Fatalf("%s: BidirectionalBFS(%d, %d) %v; no edge (%d, %d)",
+							impl, src, dst, path, path[i], path[i+1])
+					}
+				}
+			}
+		}
+	}
+}
+
+// Point-to-point queries on random graphs like those built by setupGraphs
+// in main.go, with n vertices and n edges, and on denser graphs with 4*n
+// edges, which have a giant component and hence longer searches.
+func benchmarkPointToPoint(b *testing.B, search func(g, rev Iterator, src, dst int) []int) {
+	const n = 5000
+	for _, m := range []int{n, 4 * n} {
+		b.Run(fmt.Sprintf("m=%d", m), func(b *testing.B) {
+			g := randomGraph(func(n int) Grapher { return NewHash(n) }, n, m, 1)
+			rev := Transpose(g)
+			random := rand.New(rand.NewSource(2))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				search(g, rev, random.Intn(n), random.Intn(n))
+			}
+		})
+	}
+}
+
+func BenchmarkFindPath(b *testing.B) {
+	benchmarkPointToPoint(b, func(g, _ Iterator, src, dst int) []int {
+		return FindPath(g, src, dst)
+	})
+}
+
+func BenchmarkBidirectionalBFS(b *testing.B) {
+	benchmarkPointToPoint(b, BidirectionalBFS)
+}
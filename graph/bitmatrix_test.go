@@ -0,0 +1,91 @@
+package graph_test
+
+import (
+	. "."
+	"math/rand"
+	"testing"
+)
+
+func TestBitMatrix(t *testing.T) {
+	// Compare with Matrix on unlabeled edges, across word boundaries.
+	const n = 150
+	b := NewBitMatrix(n)
+	m := NewMatrix(n)
+	random := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		v, w := random.Intn(n), random.Intn(n)
+		if i%5 == 0 {
+			b.Remove(v, w)
+			m.Remove(v, w)
+		} else {
+			b.Add(v, w)
+			m.Add(v, w)
+		}
+	}
+	b.AddBi(0, 149)
+	m.AddBi(0, 149)
+
+	if mess, diff := diff(b.NumEdges(), m.NumEdges()); diff {
+		t.Errorf("NumEdges() %s", mess)
+	}
+	for v := 0; v < n; v++ {
+		if mess, diff := diff(b.Degree(v), m.Degree(v)); diff {
+			t.Errorf("Degree(%d) %s", v, mess)
+		}
+		var bs, ms []int
+		b.DoNeighbors(v, func(w int, x interface{}) {
+			if x != NoLabel {
+				t.Errorf("DoNeighbors(%d) label %v; want NoLabel", v, x)
+			}
+			bs = append(bs, w)
+		})
+		m.DoNeighbors(v, func(w int, _ interface{}) { ms = append(ms, w) })
+		if mess, diff := diff(bs, ms); diff {
+			t.Errorf("DoNeighbors(%d) %s", v, mess)
+		}
+		for w := 0; w < n; w++ {
+			if mess, diff := diff(b.Label(v, w), m.Label(v, w)); diff {
+				t.Errorf("Label(%d, %d) %s", v, w, mess)
+			}
+		}
+	}
+
+	b.AddLabel(1, 2, 7)
+	if mess, diff := diff(b.Label(1, 2), NoLabel); diff {
+		t.Errorf("Label(1, 2) %s", mess)
+	}
+}
+
+func TestTriangles(t *testing.T) {
+	const n = 130
+	b := NewBitMatrix(n)
+	random := rand.New(rand.NewSource(2))
+	for i := 0; i < 1500; i++ {
+		b.AddBi(random.Intn(n), random.Intn(n))
+	}
+
+	// Count the triangles the slow way.
+	exp := 0
+	for u := 0; u < n; u++ {
+		for v := u + 1; v < n; v++ {
+			for w := v + 1; w < n; w++ {
+				if b.HasEdge(u, v) && b.HasEdge(v, w) && b.HasEdge(u, w) {
+					exp++
+				}
+			}
+		}
+	}
+	if mess, diff := diff(b.Triangles(), exp); diff {
+		t.Errorf("Triangles() %s", mess)
+	}
+
+	common := 0
+	for w := 0; w < n; w++ {
+		if b.HasEdge(3, w) && b.HasEdge(64, w) {
+			common++
+		}
+	}
+	if mess, diff := diff(b.CommonNeighbors(3, 64), common); diff {
+		t.Errorf("CommonNeighbors(3, 64) %s", mess)
+	}
+}
@@ -0,0 +1,57 @@
+package graph
+
+import "context"
+
+// checkInterval is the number of steps between checks for cancellation.
+const checkInterval = 1024
+
+// canceller checks a context for cancellation at the first step and then
+// every checkInterval steps, so that tight loops don't pay for a call
+// to ctx.Err at every step.
+type canceller struct {
+	ctx   context.Context
+	steps int
+	err   error
+}
+
+// step counts one step and returns the context's error,
+// which is non-nil once the context has been cancelled.
+func (c *canceller) step() error {
+	if c.err == nil {
+		if c.steps%checkInterval == 0 {
+			c.err = c.ctx.Err()
+		}
+		c.steps++
+	}
+	return c.err
+}
+
+// BFSContext is like BFS, but stops with ctx.Err() if ctx is cancelled
+// before the traversal is done. The context is checked before each call
+// to action, so action is never called if ctx is already cancelled.
+func BFSContext(ctx context.Context, g Iterator, v int, visited []bool, action func(w int)) error {
+	c := &canceller{ctx: ctx}
+	BFSUntil(g, v, visited, func(w int) bool {
+		if c.step() != nil {
+			return true
+		}
+		action(w)
+		return false
+	})
+	return c.err
+}
+
+// DFSContext is like DFS, but stops with ctx.Err() if ctx is cancelled
+// before the traversal is done. The context is checked before each call
+// to action, so action is never called if ctx is already cancelled.
+func DFSContext(ctx context.Context, g Iterator, v int, visited []bool, action func(w int)) error {
+	c := &canceller{ctx: ctx}
+	DFSUntil(g, v, visited, func(w int) bool {
+		if c.step() != nil {
+			return true
+		}
+		action(w)
+		return false
+	})
+	return c.err
+}
@@ -0,0 +1,168 @@
+package graph_test
+
+import (
+	. "."
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for impl, f := range AlgoFuncs {
+		g := grid(f, 40)
+		n := g.NumVertices()
+
+		count := 0
+		err := BFSContext(ctx, g, 0, make([]bool, n), func(int) { count++ })
+		if !errors.Is(err, context.Canceled) || count == n {
+			t.Errorf("%s: BFSContext visited %d, error %v; want cancelled", impl, count, err)
+		}
+		count = 0
+		err = DFSContext(ctx, g, 0, make([]bool, n), func(int) { count++ })
+		if !errors.Is(err, context.Canceled) || count == n {
+			t.Errorf("%s: DFSContext visited %d, error %v; want cancelled", impl, count, err)
+		}
+		if _, _, err := DijkstraContext(ctx, g, 0, intWeight); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: DijkstraContext error %v; want cancelled", impl, err)
+		}
+		if _, _, _, err := BellmanFordContext(ctx, g, 0, intWeight); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: BellmanFordContext error %v; want cancelled", impl, err)
+		}
+		zero := func(int) float64 { return 0 }
+		if _, _, _, err := AStarContext(ctx, g, 0, n-1, intWeight, zero); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: AStarContext error %v; want cancelled", impl, err)
+		}
+		if _, err := JohnsonContext(ctx, g, intWeight); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: JohnsonContext error %v; want cancelled", impl, err)
+		}
+		if m, ok := g.(*Matrix); ok {
+			if _, err := FloydWarshallContext(ctx, m, intWeight); !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: FloydWarshallContext error %v; want cancelled", impl, err)
+			}
+		}
+
+		if _, _, err := TarjanSCCContext(ctx, g); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: TarjanSCCContext error %v; want cancelled", impl, err)
+		}
+		if _, _, err := KosarajuSCCContext(ctx, g); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: KosarajuSCCContext error %v; want cancelled", impl, err)
+		}
+		if _, err := StrongComponentsContext(ctx, g); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: StrongComponentsContext error %v; want cancelled", impl, err)
+		}
+		if _, err := WeakComponentsContext(ctx, g); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: WeakComponentsContext error %v; want cancelled", impl, err)
+		}
+		if _, err := TopoSortContext(ctx, g); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: TopoSortContext error %v; want cancelled", impl, err)
+		}
+		if _, err := LexTopoSortContext(ctx, g); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: LexTopoSortContext error %v; want cancelled", impl, err)
+		}
+		if _, _, err := DepthFirstContext(ctx, g, NopVisitor{}); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: DepthFirstContext error %v; want cancelled", impl, err)
+		}
+		if _, _, err := DepthFirstFromContext(ctx, g, 0, NopVisitor{}); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: DepthFirstFromContext error %v; want cancelled", impl, err)
+		}
+		if _, err := BFSTreeContext(ctx, g, []int{0}); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: BFSTreeContext error %v; want cancelled", impl, err)
+		}
+		if _, err := BidirectionalBFSContext(ctx, g, g, 0, n-1); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: BidirectionalBFSContext error %v; want cancelled", impl, err)
+		}
+		opts := &ParallelOptions{Reverse: g}
+		if _, err := ParallelBFSContext(ctx, g, []int{0}, opts); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: ParallelBFSContext error %v; want cancelled", impl, err)
+		}
+		if _, err := FindPathContext(ctx, g, 0, n-1); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: FindPathContext error %v; want cancelled", impl, err)
+		}
+		if _, err := ReachableContext(ctx, g, 0, n-1); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: ReachableContext error %v; want cancelled", impl, err)
+		}
+		var kruskal, prim error
+		switch g := g.(type) {
+		case *Hash:
+			_, _, kruskal = g.KruskalContext(ctx, intWeight)
+			_, _, prim = g.PrimContext(ctx, intWeight)
+		case *Matrix:
+			_, _, kruskal = g.KruskalContext(ctx, intWeight)
+			_, _, prim = g.PrimContext(ctx, intWeight)
+		}
+		for alg, err := range map[string]error{"KruskalContext": kruskal, "PrimContext": prim} {
+			if !errors.Is(err, context.Canceled) {
+				t.Errorf("%s: %s error %v; want cancelled", impl, alg, err)
+			}
+		}
+
+		// Without cancellation, the whole graph is visited.
+		count = 0
+		err = BFSContext(context.Background(), g, 0, make([]bool, n), func(int) { count++ })
+		if err != nil || count != n {
+			t.Errorf("%s: BFSContext visited %d, error %v; want %d, nil", impl, count, err, n)
+		}
+	}
+}
+
+func TestContextNotCancelled(t *testing.T) {
+	ctx := context.Background()
+	for impl, f := range AlgoFuncs {
+		g := grid(f, 40)
+		n := g.NumVertices()
+		if path, err := FindPathContext(ctx, g, 0, n-1); err != nil || path == nil {
+			t.Errorf("%s: FindPathContext %v, %v; want path, nil", impl, path, err)
+		}
+		if ok, err := ReachableContext(ctx, g, 0, n-1); err != nil || !ok {
+			t.Errorf("%s: ReachableContext %v, %v; want true, nil", impl, ok, err)
+		}
+		if path, err := BidirectionalBFSContext(ctx, g, g, 0, n-1); err != nil || len(path) != 79 {
+			t.Errorf("%s: BidirectionalBFSContext %v, %v; want path of length 79, nil", impl, path, err)
+		}
+		if tree, err := BFSTreeContext(ctx, g, []int{0}); err != nil || tree.Dist(n-1) != 78 {
+			t.Errorf("%s: BFSTreeContext error %v", impl, err)
+		}
+		dist, err := ParallelBFSContext(ctx, g, []int{0}, &ParallelOptions{Reverse: g})
+		if err != nil || dist[n-1] != 78 {
+			t.Errorf("%s: ParallelBFSContext error %v", impl, err)
+		}
+		if c, err := StrongComponentsContext(ctx, g); err != nil || c.Count() != 1 {
+			t.Errorf("%s: StrongComponentsContext error %v", impl, err)
+		}
+		if _, _, err := KosarajuSCCContext(ctx, g); err != nil {
+			t.Errorf("%s: KosarajuSCCContext error %v", impl, err)
+		}
+		if _, err := TopoSortContext(ctx, g); err == nil || errors.Is(err, context.Canceled) {
+			t.Errorf("%s: TopoSortContext error %v; want a cycle", impl, err)
+		}
+	}
+}
+
+func TestContextCancelledNoAction(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	for impl, f := range AlgoFuncs {
+		g := grid(f, 3)
+		for alg, search := range map[string]func(context.Context, Iterator, int, []bool, func(int)) error{
+			"BFSContext": BFSContext,
+			"DFSContext": DFSContext,
+		} {
+			called := false
+			err := search(ctx, g, 0, make([]bool, g.NumVertices()), func(int) { called = true })
+			if !errors.Is(err, context.Canceled) || called {
+				t.Errorf("%s: %s called action %v, error %v; want false, cancelled", impl, alg, called, err)
+			}
+		}
+		vis := &recorder{}
+		if _, _, err := DepthFirstContext(ctx, g, vis); !errors.Is(err, context.Canceled) || len(vis.events) > 0 {
+			t.Errorf("%s: DepthFirstContext events %v, error %v; want none, cancelled", impl, vis.events, err)
+		}
+		if path, err := FindPathContext(ctx, g, 0, 0); !errors.Is(err, context.Canceled) {
+			t.Errorf("%s: FindPathContext(ctx, g, 0, 0) %v, %v; want cancelled", impl, path, err)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package graph
+
+import "context"
+
+// Components is a partition of the vertices of a graph into components,
+// numbered from 0 to Count()-1.
+type Components struct {
+	comp    []int   // comp[v] is the component of vertex v
+	members [][]int // members[i] lists the vertices of component i
+}
+
+// WeakComponents computes the weakly connected components of g,
+// i.e. the connected components when the direction of edges is ignored.
+// The components are numbered in order of their smallest vertex,
+// and the members of each component are listed in increasing order.
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func WeakComponents(g Iterator) *Components {
+	c, _ := WeakComponentsContext(context.Background(), g)
+	return c
+}
+
+// WeakComponentsContext is like WeakComponents, but stops with ctx.Err()
+// if ctx is cancelled before the computation is done.
+func WeakComponentsContext(ctx context.Context, g Iterator) (*Components, error) {
+	n := g.NumVertices()
+	u := NewUnionFind(n)
+	cc := &canceller{ctx: ctx}
+	for v := 0; v < n; v++ {
+		if err := cc.step(); err != nil {
+			return nil, err
+		}
+		g.DoNeighbors(v, func(w int, _ interface{}) {
+			u.Union(v, w)
+		})
+	}
+
+	c := &Components{comp: make([]int, n)}
+	id := make(map[int]int, u.Count()) // component of each root
+	for v := range c.comp {
+		r := u.Find(v)
+		i, ok := id[r]
+		if !ok {
+			i = len(c.members)
+			id[r] = i
+			c.members = append(c.members, nil)
+		}
+		c.comp[v] = i
+		c.members[i] = append(c.members[i], v)
+	}
+	return c, nil
+}
+
+// StrongComponents computes the strongly connected components of g
+// using TarjanSCC.
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func StrongComponents(g Iterator) *Components {
+	c, _ := StrongComponentsContext(context.Background(), g)
+	return c
+}
+
+// StrongComponentsContext is like StrongComponents, but stops with ctx.Err()
+// if ctx is cancelled before the computation is done.
+func StrongComponentsContext(ctx context.Context, g Iterator) (*Components, error) {
+	comp, members, err := TarjanSCCContext(ctx, g)
+	if err != nil {
+		return nil, err
+	}
+	return &Components{comp: comp, members: members}, nil
+}
+
+// Count returns the number of components. Time complexity: O(1).
+func (c *Components) Count() int {
+	return len(c.members)
+}
+
+// Size returns the number of vertices in component i.
+// Time complexity: O(1).
+func (c *Components) Size(i int) int {
+	return len(c.members[i])
+}
+
+// Largest returns the component with the most vertices,
+// or -1 if there are no components.
+// Time complexity: O(k), where k is the number of components.
+func (c *Components) Largest() int {
+	largest := -1
+	for i, m := range c.members {
+		if largest == -1 || len(m) > len(c.members[largest]) {
+			largest = i
+		}
+	}
+	return largest
+}
+
+// ComponentOf returns the component containing vertex v.
+// Time complexity: O(1).
+func (c *Components) ComponentOf(v int) int {
+	return c.comp[v]
+}
+
+// Members returns the vertices of component i.
+// The returned slice must not be modified.
+// Time complexity: O(1).
+func (c *Components) Members(i int) []int {
+	return c.members[i]
+}
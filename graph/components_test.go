@@ -0,0 +1,46 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestWeakComponents(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(7)
+		g.Add(1, 0)
+		g.Add(1, 2)
+		g.Add(3, 2)
+		g.Add(5, 4)
+		g.Add(6, 6)
+
+		c := WeakComponents(g)
+		if mess, diff := diff(c.Count(), 3); diff {
+			t.Errorf("%s: Count() %s", impl, mess)
+		}
+		if mess, diff := diff(c.Members(0), []int{0, 1, 2, 3}); diff {
+			t.Errorf("%s: Members(0) %s", impl, mess)
+		}
+		if mess, diff := diff(c.Members(1), []int{4, 5}); diff {
+			t.Errorf("%s: Members(1) %s", impl, mess)
+		}
+		if mess, diff := diff(c.Size(2), 1); diff {
+			t.Errorf("%s: Size(2) %s", impl, mess)
+		}
+		if mess, diff := diff(c.ComponentOf(5), 1); diff {
+			t.Errorf("%s: ComponentOf(5) %s", impl, mess)
+		}
+		if mess, diff := diff(c.Largest(), 0); diff {
+			t.Errorf("%s: Largest() %s", impl, mess)
+		}
+
+		s := StrongComponents(g)
+		if mess, diff := diff(s.Count(), 7); diff {
+			t.Errorf("%s: strong Count() %s", impl, mess)
+		}
+	}
+
+	if mess, diff := diff(WeakComponents(NewHash(0)).Largest(), -1); diff {
+		t.Errorf("empty Largest() %s", mess)
+	}
+}
@@ -0,0 +1,176 @@
+package graph
+
+import (
+	"iter"
+	"math"
+	"sort"
+)
+
+// CSR is an immutable graph in compressed sparse row format.
+// The neighbors of all vertices are stored in a single array,
+// sorted by vertex and then by neighbor, so a graph takes about
+// 4 bytes per edge and 8 bytes per vertex if no edge has a label.
+// Neighbors are always visited in increasing order.
+//
+// Use Freeze to construct a CSR from another graph.
+type CSR struct {
+	// The neighbors of v are targets[offsets[v]:offsets[v+1]].
+	offsets []int
+	targets []int32
+
+	// labels[i] is the label of the edge to targets[i],
+	// or labels is nil if no edge has a label.
+	labels []interface{}
+}
+
+// Freeze constructs a CSR graph with the same vertices, edges and labels
+// as g, which typically is a graph.Hash or graph.Matrix.
+// The number of vertices must be less than 2^31.
+// Time complexity: O(n + m*log d) for graph.Hash and O(n*n) for
+// graph.Matrix, where n and m are the number of vertices and edges
+// and d is the largest degree.
+func Freeze(g Iterator) *CSR {
+	n := g.NumVertices()
+	if n > math.MaxInt32 {
+		panic("graph: too many vertices for CSR")
+	}
+	c := &CSR{offsets: make([]int, n+1)}
+	if e, ok := g.(interface{ NumEdges() int }); ok {
+		c.targets = make([]int32, 0, e.NumEdges())
+	}
+	for v := 0; v < n; v++ {
+		start := len(c.targets)
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			if c.labels == nil && x != NoLabel {
+				// The first label; store labels from now on.
+				c.labels = make([]interface{}, len(c.targets), cap(c.targets))
+				for i := range c.labels {
+					c.labels[i] = NoLabel
+				}
+			}
+			c.targets = append(c.targets, int32(w))
+			if c.labels != nil {
+				c.labels = append(c.labels, x)
+			}
+		})
+		row := &csrRow{targets: c.targets[start:]}
+		if c.labels != nil {
+			row.labels = c.labels[start:]
+		}
+		if !sort.IsSorted(row) {
+			sort.Sort(row)
+		}
+		c.offsets[v+1] = len(c.targets)
+	}
+	return c
+}
+
+// csrRow sorts the neighbors of one vertex together with their labels,
+// if any.
+type csrRow struct {
+	targets []int32
+	labels  []interface{} // nil if there are no labels
+}
+
+func (r *csrRow) Len() int           { return len(r.targets) }
+func (r *csrRow) Less(i, j int) bool { return r.targets[i] < r.targets[j] }
+func (r *csrRow) Swap(i, j int) {
+	r.targets[i], r.targets[j] = r.targets[j], r.targets[i]
+	if r.labels != nil {
+		r.labels[i], r.labels[j] = r.labels[j], r.labels[i]
+	}
+}
+
+// NumVertices returns the number of vertices in this graph.
+// Time complexity: O(1).
+func (c *CSR) NumVertices() int {
+	return len(c.offsets) - 1
+}
+
+// NumEdges returns the number of (directed) edges in this graph.
+// Time complexity: O(1).
+func (c *CSR) NumEdges() int {
+	return len(c.targets)
+}
+
+// Degree returns the degree of vertex v. Time complexity: O(1).
+func (c *CSR) Degree(v int) int {
+	return c.offsets[v+1] - c.offsets[v]
+}
+
+// DoNeighbors calls action for each neighbor w of v, in increasing order,
+// with x equal to the label of the edge from v to w.
+// Time complexity: O(d), where d is the degree of v.
+func (c *CSR) DoNeighbors(v int, action func(w int, x interface{})) {
+	for i := c.offsets[v]; i < c.offsets[v+1]; i++ {
+		action(int(c.targets[i]), c.label(i))
+	}
+}
+
+// Neighbors returns an iterator over the neighbors w of v, in increasing
+// order, together with the label of the edge from v to w.
+// Time complexity: O(d), where d is the degree of v.
+func (c *CSR) Neighbors(v int) iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for i := c.offsets[v]; i < c.offsets[v+1]; i++ {
+			if !yield(int(c.targets[i]), c.label(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Vertices returns an iterator over the vertices 0 to n-1.
+func (c *CSR) Vertices() iter.Seq[int] {
+	return vertices(c.NumVertices())
+}
+
+// Edges returns an iterator over all edges in this graph.
+// Time complexity: O(n+m), where n and m are the number of
+// vertices and edges.
+func (c *CSR) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for v := 0; v < c.NumVertices(); v++ {
+			for i := c.offsets[v]; i < c.offsets[v+1]; i++ {
+				if !yield(Edge{v, int(c.targets[i]), c.label(i)}) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// HasEdge returns true if there is an edge from v to w.
+// Time complexity: O(log d), where d is the degree of v.
+func (c *CSR) HasEdge(v, w int) bool {
+	return c.find(v, w) != -1
+}
+
+// Returns the label for the edge from v to w, NoLabel if the edge has no label,
+// or nil if no such edge exists.
+// Time complexity: O(log d), where d is the degree of v.
+func (c *CSR) Label(v, w int) interface{} {
+	i := c.find(v, w)
+	if i == -1 {
+		return nil
+	}
+	return c.label(i)
+}
+
+// find returns the index in targets of the edge from v to w, or -1.
+func (c *CSR) find(v, w int) int {
+	lo, hi := c.offsets[v], c.offsets[v+1]
+	i := lo + sort.Search(hi-lo, func(i int) bool { return int(c.targets[lo+i]) >= w })
+	if i < hi && int(c.targets[i]) == w {
+		return i
+	}
+	return -1
+}
+
+// label returns the label of the edge at index i in targets.
+func (c *CSR) label(i int) interface{} {
+	if c.labels == nil {
+		return NoLabel
+	}
+	return c.labels[i]
+}
@@ -0,0 +1,83 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestFreeze(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		_, g1, g5 := setup(f)
+		g5.Add(0, 4)
+		g5.Add(0, 2)
+
+		c1 := Freeze(g1)
+		if mess, diff := diff(c1.Label(0, 0), NoLabel); diff {
+			t.Errorf("%s: c1.Label(0, 0) %s", impl, mess)
+		}
+
+		c := Freeze(g5)
+		if mess, diff := diff(c.NumVertices(), 5); diff {
+			t.Errorf("%s: NumVertices() %s", impl, mess)
+		}
+		if mess, diff := diff(c.NumEdges(), 4); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		if mess, diff := diff(c.Degree(0), 3); diff {
+			t.Errorf("%s: Degree(0) %s", impl, mess)
+		}
+		if mess, diff := diff(c.Degree(4), 0); diff {
+			t.Errorf("%s: Degree(4) %s", impl, mess)
+		}
+		for v := 0; v < 5; v++ {
+			for w := 0; w < 5; w++ {
+				if mess, diff := diff(c.HasEdge(v, w), g5.HasEdge(v, w)); diff {
+					t.Errorf("%s: HasEdge(%d, %d) %s", impl, v, w, mess)
+				}
+				if mess, diff := diff(c.Label(v, w), g5.Label(v, w)); diff {
+					t.Errorf("%s: Label(%d, %d) %s", impl, v, w, mess)
+				}
+			}
+		}
+		var ns []int
+		c.DoNeighbors(0, func(w int, _ interface{}) { ns = append(ns, w) })
+		if mess, diff := diff(ns, []int{1, 2, 4}); diff {
+			t.Errorf("%s: DoNeighbors(0) %s", impl, mess)
+		}
+		count := 0
+		for e := range c.Edges() {
+			if mess, diff := diff(e.Label, g5.Label(e.From, e.To)); diff {
+				t.Errorf("%s: Edges() label of (%d, %d) %s", impl, e.From, e.To, mess)
+			}
+			count++
+		}
+		if mess, diff := diff(count, 4); diff {
+			t.Errorf("%s: Edges() #it %s", impl, mess)
+		}
+	}
+}
+
+// Runs DFS from every unvisited vertex, like runDFS in main.go.
+func benchmarkDFS(b *testing.B, g Iterator) {
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		state := make([]bool, g.NumVertices())
+		for v, visited := range state {
+			if !visited {
+				DFS(g, v, state, func(int) {})
+			}
+		}
+	}
+}
+
+func BenchmarkDFSHash(b *testing.B) {
+	benchmarkDFS(b, randomGraph(func(n int) Grapher { return NewHash(n) }, 5000, 5000, 1))
+}
+
+func BenchmarkDFSMatrix(b *testing.B) {
+	benchmarkDFS(b, randomGraph(func(n int) Grapher { return NewMatrix(n) }, 5000, 5000, 1))
+}
+
+func BenchmarkDFSCSR(b *testing.B) {
+	benchmarkDFS(b, Freeze(randomGraph(func(n int) Grapher { return NewHash(n) }, 5000, 5000, 1)))
+}
@@ -24,3 +24,26 @@ func ExampleDFS() {
 	}
 	// Output: {0}{123}{4}
 }
+
+func ExampleNewSortedHash() {
+
+	g := graph.NewSortedHash(6)
+
+	g.AddBi(0, 5)
+	g.AddBi(0, 3)
+	g.AddBi(0, 1)
+	g.AddBi(3, 4)
+	g.AddBi(1, 2)
+
+	// The neighbors are visited in increasing order,
+	// so the traversal order is the same in every run.
+	state := make([]bool, g.NumVertices())
+	graph.BFS(g, 0, state, func(w int) { fmt.Print(w) })
+	fmt.Println()
+	state = make([]bool, g.NumVertices())
+	graph.DFS(g, 0, state, func(w int) { fmt.Print(w) })
+	fmt.Println()
+	// Output:
+	// 013524
+	// 013542
+}
@@ -0,0 +1,95 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNegativeWeight is returned by algorithms that require
+// non-negative edge weights when a negative weight is encountered.
+var ErrNegativeWeight = errors.New("graph: negative edge weight")
+
+// Dijkstra computes the shortest paths in g from s to all other vertices.
+// The weight of an edge is computed by calling weight with the label
+// of the edge; weights must be non-negative.
+//
+// dist[v] is the length of a shortest path from s to v, or +Inf if
+// v is not reachable from s. parent[v] is the predecessor of v on such
+// a path, or -1 if v is s or not reachable. Use Path to reconstruct
+// a path from the parent array.
+//
+// If a negative weight is found, the search stops and an error
+// wrapping ErrNegativeWeight is returned.
+//
+// Time complexity: O((n+m)log n) for graph.Hash and O(n*n + m*log n)
+// for graph.Matrix, where n and m are the number of vertices and edges.
+func Dijkstra(g Iterator, s int, weight func(x interface{}) float64) (dist []float64, parent []int, err error) {
+	return DijkstraContext(context.Background(), g, s, weight)
+}
+
+// DijkstraContext is like Dijkstra, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func DijkstraContext(ctx context.Context, g Iterator, s int, weight func(x interface{}) float64) (dist []float64, parent []int, err error) {
+	n := g.NumVertices()
+	dist = make([]float64, n)
+	parent = make([]int, n)
+	for v := range dist {
+		dist[v] = math.Inf(1)
+		parent[v] = -1
+	}
+	done := make([]bool, n)
+
+	dist[s] = 0
+	c := &canceller{ctx: ctx}
+	q := &pqueue{}
+	q.push(s, 0)
+	for q.Len() > 0 && err == nil {
+		if err = c.step(); err != nil {
+			break
+		}
+		v, d := q.pop()
+		if done[v] || d > dist[v] {
+			continue // stale queue entry
+		}
+		done[v] = true
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			if err != nil {
+				return
+			}
+			c := weight(x)
+			if c < 0 {
+				err = fmt.Errorf("%w: edge (%d, %d) has weight %v", ErrNegativeWeight, v, w, c)
+				return
+			}
+			if alt := d + c; alt < dist[w] {
+				dist[w] = alt
+				parent[w] = v
+				q.push(w, alt)
+			}
+		})
+	}
+	return
+}
+
+// Path returns the vertices on the path from s to v described by
+// the parent array, starting with s and ending with v.
+// It returns nil if v cannot be reached from s through parent.
+func Path(parent []int, s, v int) []int {
+	var path []int
+	for w := v; w != -1; w = parent[w] {
+		path = append(path, w)
+		if w == s {
+			// Reverse the path in place.
+			for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+				path[i], path[j] = path[j], path[i]
+			}
+			return path
+		}
+		if len(path) > len(parent) {
+			break // parent contains a cycle
+		}
+	}
+	return nil
+}
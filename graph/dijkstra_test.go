@@ -0,0 +1,52 @@
+package graph_test
+
+import (
+	. "."
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestDijkstra(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(6)
+		g.AddLabel(0, 1, 7)
+		g.AddLabel(0, 2, 9)
+		g.AddLabel(0, 5, 14)
+		g.AddLabel(1, 2, 10)
+		g.AddLabel(1, 3, 15)
+		g.AddLabel(2, 3, 11)
+		g.AddLabel(2, 5, 2)
+		g.AddLabel(3, 4, 6)
+
+		dist, parent, err := Dijkstra(g, 0, intWeight)
+		if err != nil {
+			t.Fatalf("%s: Dijkstra error %v", impl, err)
+		}
+		exp := []float64{0, 7, 9, 20, 26, 11}
+		for v, d := range exp {
+			if mess, diff := diff(dist[v], d); diff {
+				t.Errorf("%s: dist[%d] %s", impl, v, mess)
+			}
+		}
+		if mess, diff := diff(Path(parent, 0, 4), []int{0, 2, 3, 4}); diff {
+			t.Errorf("%s: Path(parent, 0, 4) %s", impl, mess)
+		}
+		if mess, diff := diff(Path(parent, 0, 0), []int{0}); diff {
+			t.Errorf("%s: Path(parent, 0, 0) %s", impl, mess)
+		}
+
+		dist, parent, _ = Dijkstra(g, 4, intWeight)
+		if mess, diff := diff(math.IsInf(dist[0], 1), true); diff {
+			t.Errorf("%s: dist[0] from 4 is +Inf %s", impl, mess)
+		}
+		if Path(parent, 4, 0) != nil {
+			t.Errorf("%s: Path(parent, 4, 0) %v; want nil", impl, Path(parent, 4, 0))
+		}
+
+		g.AddLabel(5, 4, -1)
+		if _, _, err := Dijkstra(g, 0, intWeight); !errors.Is(err, ErrNegativeWeight) {
+			t.Errorf("%s: Dijkstra error %v; want ErrNegativeWeight", impl, err)
+		}
+	}
+}
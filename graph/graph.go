@@ -1,19 +1,37 @@
-// Package graph implements datastructures for a graph with a fixed number of vertices.
+// Package graph implements datastructures for graphs.
 //
 // The vertices are numbered from 0 to n-1.
 // Edges may be added or removed from the graph.
+// Vertices may be added with AddVertex, and removed with RemoveVertex,
+// which renumbers the last vertex to keep the numbering contiguous.
 // Each edge may have an associated label of interface{} type.
+// Each vertex may also have a label, set with SetVertexLabel.
 //
 // graph.Hash is best suited for sparse graphs.
-// The edges are represented by adjacency lists implemented as hash maps.
+// The edges are represented by adjacency lists implemented as hash maps,
+// together with a hash set of predecessors for each vertex, which makes
+// InDegree and DoPredecessors cheap at the cost of about twice the space.
 // Hence, space complexity is Θ(n+m), where n and m are the number of
 // vertices and edges.
+// Neighbors are visited in random order, unless the graph
+// is constructed with NewSortedHash.
 //
 // graph.Matrix is best suited for dense graphs.
 // The edges are represented by an adjacency matrix.
 // Hence, space complexity is Θ(n*n), where n is the number of vertices.
+//
+// graph.Undirected wraps a Hash or Matrix, storing each undirected edge
+// in both directions.
+//
+// graph.Multi allows several edges, each with its own label,
+// between the same pair of vertices.
 package graph
 
+import (
+	"context"
+	"iter"
+)
+
 // NoLabel represents an edge with no label.
 var NoLabel noLabel
 
@@ -21,6 +39,12 @@ type noLabel struct{} // a type with only one value
 
 func (x noLabel) String() string { return "NoLabel" }
 
+// Edge is a directed edge from From to To with label Label.
+type Edge struct {
+	From, To int
+	Label    interface{}
+}
+
 type Iterator interface {
 	// NumVertices returns the number of vertices.
 	NumVertices() int
@@ -30,13 +54,43 @@ type Iterator interface {
 	DoNeighbors(v int, action func(w int, x interface{}))
 }
 
+// PredecessorIterator is implemented by graphs that can list
+// the edges to a vertex, such as Hash and Matrix.
+type PredecessorIterator interface {
+	// NumVertices returns the number of vertices.
+	NumVertices() int
+
+	// DoPredecessors calls action for each vertex u with an edge to v,
+	// with x equal to the label of the edge from u to v.
+	DoPredecessors(v int, action func(u int, x interface{}))
+}
+
+// Reversed returns a view of g with every edge reversed: the neighbors
+// of v are the predecessors of v in g, with the same labels.
+// Unlike Transpose, it doesn't copy g, so it reflects later changes to g.
+// For example, the reverse graph needed by BidirectionalBFS
+// may be given as Reversed(g).
+func Reversed(g PredecessorIterator) Iterator {
+	return reversed{g}
+}
+
+type reversed struct {
+	g PredecessorIterator
+}
+
+func (r reversed) NumVertices() int { return r.g.NumVertices() }
+
+func (r reversed) DoNeighbors(v int, action func(w int, x interface{})) {
+	r.g.DoPredecessors(v, action)
+}
+
 // BFS traverses the vertices of g that have not yet been visited
 // in breath-first order starting at v.
 // The visited array keeps track of visited vertices.
 // When the algorithm arrives at a node w for which visited[w] is false,
 // action(w) is called and visited[w] is set to true.
 func BFS(g Iterator, v int, visited []bool, action func(w int)) {
-	traverse(g, v, visited, action, bfs)
+	traverse(g, []int{v}, visited, func(w, _ int) bool { action(w); return false }, bfs)
 }
 
 // DFS traverses the vertices of g that have not yet been visited
@@ -45,7 +99,94 @@ func BFS(g Iterator, v int, visited []bool, action func(w int)) {
 // When the algorithm arrives at a node w for which visited[w] is false,
 // action(w) is called and visited[w] is set to true.
 func DFS(g Iterator, v int, visited []bool, action func(w int)) {
-	traverse(g, v, visited, action, dfs)
+	traverse(g, []int{v}, visited, func(w, _ int) bool { action(w); return false }, dfs)
+}
+
+// BFSUntil is like BFS, but stops the traversal as soon as
+// action returns true. It reports whether the traversal was stopped.
+func BFSUntil(g Iterator, v int, visited []bool, action func(w int) bool) bool {
+	return traverse(g, []int{v}, visited, func(w, _ int) bool { return action(w) }, bfs)
+}
+
+// DFSUntil is like DFS, but stops the traversal as soon as
+// action returns true. It reports whether the traversal was stopped.
+func DFSUntil(g Iterator, v int, visited []bool, action func(w int) bool) bool {
+	return traverse(g, []int{v}, visited, func(w, _ int) bool { return action(w) }, dfs)
+}
+
+// BFSSeq returns an iterator over the vertices reachable from v
+// in breath-first order. The traversal stops when the loop body breaks.
+func BFSSeq(g Iterator, v int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		visited := make([]bool, g.NumVertices())
+		BFSUntil(g, v, visited, func(w int) bool { return !yield(w) })
+	}
+}
+
+// DFSSeq returns an iterator over the vertices reachable from v
+// in depth-first order. The traversal stops when the loop body breaks.
+func DFSSeq(g Iterator, v int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		visited := make([]bool, g.NumVertices())
+		DFSUntil(g, v, visited, func(w int) bool { return !yield(w) })
+	}
+}
+
+// Reachable returns true if there is a path from src to dst in g.
+// The search stops as soon as dst is found.
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix
+// in the worst case, where n and m are the number of vertices and edges.
+func Reachable(g Iterator, src, dst int) bool {
+	ok, _ := ReachableContext(context.Background(), g, src, dst)
+	return ok
+}
+
+// ReachableContext is like Reachable, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func ReachableContext(ctx context.Context, g Iterator, src, dst int) (bool, error) {
+	visited := make([]bool, g.NumVertices())
+	c := &canceller{ctx: ctx}
+	found := DFSUntil(g, src, visited, func(w int) bool {
+		return c.step() != nil || w == dst
+	})
+	if c.err != nil {
+		return false, c.err
+	}
+	return found, nil
+}
+
+// FindPath returns a path from src to dst in g with as few edges
+// as possible, starting with src and ending with dst,
+// or nil if there is no such path.
+// The search stops as soon as dst is found.
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix
+// in the worst case, where n and m are the number of vertices and edges.
+func FindPath(g Iterator, src, dst int) []int {
+	path, _ := FindPathContext(context.Background(), g, src, dst)
+	return path
+}
+
+// FindPathContext is like FindPath, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func FindPathContext(ctx context.Context, g Iterator, src, dst int) ([]int, error) {
+	n := g.NumVertices()
+	visited := make([]bool, n)
+	parent := make([]int, n)
+	c := &canceller{ctx: ctx}
+	found := traverse(g, []int{src}, visited, func(w, p int) bool {
+		if c.step() != nil {
+			return true
+		}
+		parent[w] = p
+		return w == dst
+	}, bfs)
+	if c.err != nil {
+		return nil, c.err
+	}
+	if !found {
+		return nil, nil
+	}
+	return Path(parent, src, dst), nil
 }
 
 const (
@@ -53,14 +194,22 @@ const (
 	dfs
 )
 
-func traverse(g Iterator, v int, visited []bool, action func(w int), order int) {
+// traverse visits the unvisited vertices reachable from the sources
+// in the given order. For each vertex w, action(w, p) is called, where p
+// is the vertex from which w was reached, or -1 if w is a source.
+// The traversal stops as soon as action returns true,
+// in which case traverse returns true.
+func traverse(g Iterator, sources []int, visited []bool, action func(w, parent int) bool, order int) bool {
 	var queue []int
 
-	if visited[v] {
-		return
+	for _, v := range sources {
+		if !visited[v] && visit(v, -1, &queue, visited, action) {
+			return true
+		}
 	}
-	visit(v, &queue, visited, action)
-	for len(queue) > 0 {
+	var v int
+	stop := false
+	for len(queue) > 0 && !stop {
 		switch order {
 		case bfs: // pop from fifo queue
 			v, queue = queue[0], queue[1:]
@@ -69,15 +218,27 @@ func traverse(g Iterator, v int, visited []bool, action func(w int), order int)
 			v, queue = queue[i], queue[:i]
 		}
 		g.DoNeighbors(v, func(w int, _ interface{}) {
-			if !visited[w] {
-				visit(w, &queue, visited, action)
+			if !stop && !visited[w] {
+				stop = visit(w, v, &queue, visited, action)
 			}
 		})
 	}
+	return stop
 }
 
-func visit(v int, queue *[]int, visited []bool, action func(w int)) {
+func visit(v, parent int, queue *[]int, visited []bool, action func(w, parent int) bool) bool {
 	visited[v] = true
-	action(v)
 	*queue = append(*queue, v)
+	return action(v, parent)
+}
+
+// vertices returns an iterator over the integers 0 to n-1.
+func vertices(n int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		for v := 0; v < n; v++ {
+			if !yield(v) {
+				return
+			}
+		}
+	}
 }
@@ -13,6 +13,7 @@ package graph_test
 import (
 	. "."
 	"fmt"
+	"iter"
 	"sort"
 	"strconv"
 	"testing"
@@ -38,10 +39,24 @@ var NewFuncs = map[string]func(int) Grapher{
 	//test the hash version
 	"Hash": func(n int) Grapher { return NewHash(n) },
 
+	//test the hash version with sorted neighbors
+	"SortedHash": func(n int) Grapher { return NewSortedHash(n) },
+
 	//test the matrix version
 	//"Matrix": func(n int) Grapher { return NewMatrix(n) },
 }
 
+// Factory methods used by the algorithm tests,
+// which should give the same results for both versions.
+var AlgoFuncs = map[string]func(int) Grapher{
+	"Hash":       func(n int) Grapher { return NewHash(n) },
+	"SortedHash": func(n int) Grapher { return NewSortedHash(n) },
+	"Matrix":     func(n int) Grapher { return NewMatrix(n) },
+}
+
+// Converts an int edge label to a weight.
+func intWeight(x interface{}) float64 { return float64(x.(int)) }
+
 // Constructs test graphs using the factory method f.
 func setup(f func(int) Grapher) (g0, g1, g5 Grapher) {
 	g0 = f(0)
@@ -518,3 +533,236 @@ func TestBFS(t *testing.T) {
 		}
 	}
 }
+
+func TestTraverseUntil(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(6)
+		for v := 0; v+1 < g.NumVertices(); v++ {
+			g.AddBi(v, v+1)
+		}
+
+		for alg, until := range map[string]func(Iterator, int, []bool, func(int) bool) bool{
+			"BFSUntil": BFSUntil,
+			"DFSUntil": DFSUntil,
+		} {
+			count := 0
+			state := make([]bool, g.NumVertices())
+			stopped := until(g, 0, state, func(w int) bool {
+				count++
+				return w == 2
+			})
+			if mess, diff := diff(stopped, true); diff {
+				t.Errorf("%s: %s stopped %s", impl, alg, mess)
+			}
+			if mess, diff := diff(count, 3); diff {
+				t.Errorf("%s: %s #visited %s", impl, alg, mess)
+			}
+
+			count = 0
+			state = make([]bool, g.NumVertices())
+			stopped = until(g, 0, state, func(w int) bool {
+				count++
+				return false
+			})
+			if mess, diff := diff(stopped, false); diff {
+				t.Errorf("%s: %s stopped %s", impl, alg, mess)
+			}
+			if mess, diff := diff(count, 6); diff {
+				t.Errorf("%s: %s #visited %s", impl, alg, mess)
+			}
+		}
+	}
+}
+
+func TestFindPath(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(6)
+		g.Add(0, 1)
+		g.Add(1, 2)
+		g.Add(2, 3)
+		g.Add(0, 4)
+		g.Add(4, 3)
+
+		if mess, diff := diff(FindPath(g, 0, 3), []int{0, 4, 3}); diff {
+			t.Errorf("%s: FindPath(g, 0, 3) %s", impl, mess)
+		}
+		if mess, diff := diff(FindPath(g, 2, 2), []int{2}); diff {
+			t.Errorf("%s: FindPath(g, 2, 2) %s", impl, mess)
+		}
+		if FindPath(g, 3, 0) != nil {
+			t.Errorf("%s: FindPath(g, 3, 0) %v; want nil", impl, FindPath(g, 3, 0))
+		}
+		if mess, diff := diff(Reachable(g, 1, 3), true); diff {
+			t.Errorf("%s: Reachable(g, 1, 3) %s", impl, mess)
+		}
+		if mess, diff := diff(Reachable(g, 1, 4), false); diff {
+			t.Errorf("%s: Reachable(g, 1, 4) %s", impl, mess)
+		}
+		if mess, diff := diff(Reachable(g, 5, 5), true); diff {
+			t.Errorf("%s: Reachable(g, 5, 5) %s", impl, mess)
+		}
+	}
+}
+
+// Implemented by both versions, but not part of Grapher.
+type Ranger interface {
+	Neighbors(int) iter.Seq2[int, interface{}]
+	Vertices() iter.Seq[int]
+	Edges() iter.Seq[Edge]
+}
+
+func TestRangeFunc(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		_, g1, g5 := setup(f)
+		g5.Add(0, 4)
+		r1, r5 := g1.(Ranger), g5.(Ranger)
+
+		count := 0
+		for w, x := range r1.Neighbors(0) {
+			if mess, diff := diff(w, 0); diff {
+				t.Errorf("%s: g1.Neighbors(0) w: %s", impl, mess)
+			}
+			if mess, diff := diff(x, NoLabel); diff {
+				t.Errorf("%s: g1.Neighbors(0) x: %s", impl, mess)
+			}
+			count++
+		}
+		if mess, diff := diff(count, 1); diff {
+			t.Errorf("%s: g1.Neighbors(0) #it: %s", impl, mess)
+		}
+
+		count = 0
+		for range r5.Neighbors(0) {
+			count++
+			break
+		}
+		if mess, diff := diff(count, 1); diff {
+			t.Errorf("%s: g5.Neighbors(0) #it after break: %s", impl, mess)
+		}
+
+		vs := ""
+		for v := range r5.Vertices() {
+			vs += strconv.Itoa(v)
+		}
+		if mess, diff := diff(vs, "01234"); diff {
+			t.Errorf("%s: g5.Vertices() %s", impl, mess)
+		}
+
+		count = 0
+		for e := range r5.Edges() {
+			if mess, diff := diff(g5.Label(e.From, e.To), e.Label); diff {
+				t.Errorf("%s: g5.Edges() label of (%d, %d) %s", impl, e.From, e.To, mess)
+			}
+			count++
+		}
+		if mess, diff := diff(count, g5.NumEdges()); diff {
+			t.Errorf("%s: g5.Edges() #it: %s", impl, mess)
+		}
+	}
+}
+
+func TestTraverseSeq(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(6)
+		for v := 0; v+1 < g.NumVertices(); v++ {
+			g.AddBi(v, v+1)
+		}
+		for alg, seq := range map[string]func(Iterator, int) iter.Seq[int]{
+			"BFSSeq": BFSSeq,
+			"DFSSeq": DFSSeq,
+		} {
+			res := ""
+			for v := range seq(g, 2) {
+				res += strconv.Itoa(v)
+			}
+			if mess, diff := diffPerm(res, "012345"); diff {
+				t.Errorf("%s: %s %s", impl, alg, mess)
+			}
+
+			res = ""
+			for v := range seq(g, 0) {
+				if v == 3 {
+					break
+				}
+				res += strconv.Itoa(v)
+			}
+			if mess, diff := diff(res, "012"); diff {
+				t.Errorf("%s: %s with break %s", impl, alg, mess)
+			}
+		}
+	}
+}
+
+// Implemented by both versions, but not part of Grapher.
+type VertexSetter interface {
+	AddVertex() int
+	RemoveVertex(int) int
+}
+
+func TestAddRemoveVertex(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(0)
+		vs := g.(VertexSetter)
+		for i := 0; i < 5; i++ {
+			if mess, diff := diff(vs.AddVertex(), i); diff {
+				t.Errorf("%s: AddVertex() %s", impl, mess)
+			}
+		}
+		g.AddLabel(0, 1, 1)
+		g.AddLabel(1, 4, 2)
+		g.AddLabel(4, 4, 3)
+		g.AddLabel(4, 0, 4)
+		g.AddLabel(2, 3, 5)
+		g.AddLabel(3, 1, 6)
+
+		// Vertex 4 becomes vertex 1.
+		if mess, diff := diff(vs.RemoveVertex(1), 4); diff {
+			t.Errorf("%s: RemoveVertex(1) %s", impl, mess)
+		}
+		if mess, diff := diff(g.NumVertices(), 4); diff {
+			t.Errorf("%s: NumVertices() %s", impl, mess)
+		}
+		if mess, diff := diff(g.NumEdges(), 3); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		for _, e := range [][3]int{{1, 1, 3}, {1, 0, 4}, {2, 3, 5}} {
+			if mess, diff := diff(g.Label(e[0], e[1]), e[2]); diff {
+				t.Errorf("%s: Label(%d, %d) %s", impl, e[0], e[1], mess)
+			}
+		}
+		if mess, diff := diff(g.Degree(0), 0); diff {
+			t.Errorf("%s: Degree(0) %s", impl, mess)
+		}
+		if mess, diff := diff(g.Degree(3), 0); diff {
+			t.Errorf("%s: Degree(3) %s", impl, mess)
+		}
+
+		if mess, diff := diff(vs.RemoveVertex(3), -1); diff {
+			t.Errorf("%s: RemoveVertex(3) %s", impl, mess)
+		}
+		if mess, diff := diff(g.NumEdges(), 2); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+
+		v := vs.AddVertex()
+		if mess, diff := diff(v, 3); diff {
+			t.Errorf("%s: AddVertex() %s", impl, mess)
+		}
+		if mess, diff := diff(g.Degree(v), 0); diff {
+			t.Errorf("%s: Degree(%d) %s", impl, v, mess)
+		}
+		g.Add(v, 1)
+		g.Add(1, v)
+		var ns []int
+		g.DoNeighbors(1, func(w int, _ interface{}) { ns = append(ns, w) })
+		if impl == "Hash" {
+			sort.Ints(ns) // only the other versions keep neighbors in order
+		}
+		if mess, diff := diff(ns, []int{0, 1, 3}); diff {
+			t.Errorf("%s: DoNeighbors(1) %s", impl, mess)
+		}
+		if mess, diff := diff(g.NumEdges(), 4); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+	}
+}
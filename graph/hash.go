@@ -1,5 +1,10 @@
 package graph
 
+import (
+	"iter"
+	"sort"
+)
+
 /*
 	This is a class representing the hash version of the Graph.
 	A Graph consists of edges connected to each other by verices.
@@ -16,12 +21,40 @@ type Hash struct {
 	// The maps may be nil and are allocated only when needed.
 	edges []map[int]interface{}
 
+	// If not nil, sorted[v] lists the neighbors of v in increasing order,
+	// and neighbors are always visited in this order.
+	sorted [][]int
+
+	// The set in[v] contains u if there is an edge from u to v.
+	// Like the edge maps, the sets are allocated only when needed.
+	in []map[int]struct{}
+
+	vlabels vertexLabels // the labels of the vertices
+
 	numEdges int // total number of directed edges in the graph
 }
 
 // NewList constructs a new graph with n vertices and no edges.
 func NewHash(n int) *Hash {
-	return &Hash{edges: make([]map[int]interface{}, n)}
+	return &Hash{edges: make([]map[int]interface{}, n), in: make([]map[int]struct{}, n)}
+}
+
+// NewSortedHash constructs a new graph with n vertices and no edges,
+// which visits the neighbors of each vertex in increasing order.
+// This makes DoNeighbors, and hence BFS and DFS, deterministic.
+//
+// Besides the hash maps of neighbors and predecessors, the graph keeps
+// a sorted slice of neighbors for each vertex. Label and HasEdge are
+// still O(1), and DoNeighbors is still O(m), where m is the number of
+// neighbors, but adding or removing an edge from v takes O(d) time,
+// where d is the degree of v. Each edge is then stored in three places
+// rather than two: its neighbor map, its predecessor set and a slice.
+func NewSortedHash(n int) *Hash {
+	return &Hash{
+		edges:  make([]map[int]interface{}, n),
+		sorted: make([][]int, n),
+		in:     make([]map[int]struct{}, n),
+	}
 }
 
 // NumVertices returns the number of vertices in this graph.
@@ -36,7 +69,8 @@ func (g *Hash) NumEdges() int {
 	return g.numEdges
 }
 
-// Degree returns the degree of vertex v. Time complexity: O(1).
+// Degree returns the degree of vertex v, the number of edges from v.
+// Time complexity: O(1).
 func (g *Hash) Degree(v int) int {
 	//the degree is how many neighbours the node v has
 
@@ -51,6 +85,14 @@ func (g *Hash) Degree(v int) int {
 // Time complexity: O(m), where m is the number of neighbors.
 func (g *Hash) DoNeighbors(v int, action func(w int, x interface{})) {
 
+	//in sorted mode, visit the neighbours in order
+	if g.sorted != nil {
+		for _, w := range g.sorted[v] {
+			action(w, g.edges[v][w])
+		}
+		return
+	}
+
 	//first we need to get all the neighbours w of v
 	//we get the neighbours by looking in the edges
 	w_plural := g.edges[v]
@@ -63,6 +105,47 @@ func (g *Hash) DoNeighbors(v int, action func(w int, x interface{})) {
 
 }
 
+// Neighbors returns an iterator over the neighbors w of v,
+// together with the label of the edge from v to w.
+// Time complexity: O(m), where m is the number of neighbors.
+func (g *Hash) Neighbors(v int) iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		if g.sorted != nil {
+			for _, w := range g.sorted[v] {
+				if !yield(w, g.edges[v][w]) {
+					return
+				}
+			}
+			return
+		}
+		for w, label := range g.edges[v] {
+			if !yield(w, label) {
+				return
+			}
+		}
+	}
+}
+
+// Vertices returns an iterator over the vertices 0 to n-1.
+func (g *Hash) Vertices() iter.Seq[int] {
+	return vertices(len(g.edges))
+}
+
+// Edges returns an iterator over all edges in this graph.
+// Time complexity: O(n+m), where n and m are the number of
+// vertices and edges.
+func (g *Hash) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for v := range g.edges {
+			for w, label := range g.Neighbors(v) {
+				if !yield(Edge{v, w, label}) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // HasEdge returns true if there is an edge from v to w.
 // Time complexity: O(1).
 func (g *Hash) HasEdge(v, w int) bool {
@@ -104,7 +187,7 @@ func (g *Hash) Label(v, w int) interface{} {
 
 // Add inserts a directed edge.
 // It removes any previous label if this edge already exists.
-// Time complexity: O(1).
+// Time complexity: O(1), or O(d) for a sorted graph (see NewSortedHash).
 func (g *Hash) Add(from, to int) {
 
 	neighbours_from := g.edges[from]
@@ -113,6 +196,8 @@ func (g *Hash) Add(from, to int) {
 	if _, hasValue := neighbours_from[to]; !hasValue {
 		//increase edges
 		g.numEdges += 1
+		g.insertSorted(from, to)
+		g.addIn(from, to)
 	}
 
 	//if no neighbours - init the slice
@@ -129,7 +214,7 @@ func (g *Hash) Add(from, to int) {
 
 // AddLabel inserts a directed edge with label x.
 // It overwrites any previous label if this edge already exists.
-// Time complexity: O(1).
+// Time complexity: O(1), or O(d) for a sorted graph (see NewSortedHash).
 func (g *Hash) AddLabel(from, to int, x interface{}) {
 	m := g.edges[from]
 	if m == nil {
@@ -138,13 +223,15 @@ func (g *Hash) AddLabel(from, to int, x interface{}) {
 	}
 	if _, ok := m[to]; !ok {
 		g.numEdges++
+		g.insertSorted(from, to)
+		g.addIn(from, to)
 	}
 	m[to] = x
 }
 
 // AddBi inserts edges between v and w.
 // It removes any previous labels if these edges already exists.
-// Time complexity: O(1).
+// Time complexity: O(1), or O(d) for a sorted graph (see NewSortedHash).
 func (g *Hash) AddBi(v, w int) {
 	//use add function to add edges in both directions
 	g.Add(w, v)
@@ -153,7 +240,7 @@ func (g *Hash) AddBi(v, w int) {
 
 // AddBiLabel inserts edges with label x between v and w.
 // It overwrites any previous labels if these edges already exists.
-// Time complexity: O(1).
+// Time complexity: O(1), or O(d) for a sorted graph (see NewSortedHash).
 func (g *Hash) AddBiLabel(v, w int, x interface{}) {
 
 	//use addLabel function to add labels in both directions
@@ -162,7 +249,8 @@ func (g *Hash) AddBiLabel(v, w int, x interface{}) {
 
 }
 
-// Remove removes an edge. Time complexity: O(1).
+// Remove removes an edge.
+// Time complexity: O(1), or O(d) for a sorted graph (see NewSortedHash).
 func (g *Hash) Remove(from, to int) {
 
 	//check if the edge exists
@@ -170,11 +258,14 @@ func (g *Hash) Remove(from, to int) {
 		//if it exists - remove
 		g.numEdges -= 1
 		delete(g.edges[from], to)
+		delete(g.in[to], from)
+		g.removeSorted(from, to)
 	}
 
 }
 
-// RemoveBi removes all edges between v and w. Time complexity: O(1).
+// RemoveBi removes all edges between v and w.
+// Time complexity: O(1), or O(d) for a sorted graph (see NewSortedHash).
 func (g *Hash) RemoveBi(v, w int) {
 
 	//use the Remove function above to delete edges in both directions
@@ -183,3 +274,213 @@ func (g *Hash) RemoveBi(v, w int) {
 	g.Remove(v, w)
 
 }
+
+// AddVertex adds a new vertex with no edges to this graph
+// and returns its number, which is the old number of vertices.
+// Time complexity: O(1) amortized.
+func (g *Hash) AddVertex() int {
+	g.edges = append(g.edges, nil)
+	g.in = append(g.in, nil)
+	if g.sorted != nil {
+		g.sorted = append(g.sorted, nil)
+	}
+	g.vlabels.add()
+	return len(g.edges) - 1
+}
+
+// RemoveVertex removes vertex v and all edges to and from v.
+// To keep the vertices numbered from 0 to n-2, the last vertex n-1
+// is renumbered v. RemoveVertex returns the old number of the vertex
+// that now has number v, or -1 if v was the last vertex.
+// Time complexity: O(d), where d is the sum of the in- and out-degrees
+// of v and n-1, or O(d*d) for a sorted graph.
+func (g *Hash) RemoveVertex(v int) (moved int) {
+	last := len(g.edges) - 1
+
+	//remove the edges from v and the edges to v
+	for w := range g.edges[v] {
+		g.Remove(v, w)
+	}
+	for u := range g.in[v] {
+		g.Remove(u, v)
+	}
+
+	moved = -1
+	if v != last {
+		//renumber the last vertex: first the edges to it, then the edges from it
+		moved = last
+		for u := range g.in[last] {
+			if u == last {
+				continue // a self-loop, renumbered below
+			}
+			m := g.edges[u]
+			m[v] = m[last]
+			delete(m, last)
+			g.removeSorted(u, last)
+			g.insertSorted(u, v)
+		}
+		for w := range g.edges[last] {
+			if w == last {
+				continue
+			}
+			delete(g.in[w], last)
+			g.in[w][v] = struct{}{}
+		}
+		if x, ok := g.edges[last][last]; ok {
+			delete(g.edges[last], last)
+			g.edges[last][v] = x
+			delete(g.in[last], last)
+			g.in[last][v] = struct{}{}
+			g.removeSorted(last, last)
+			g.insertSorted(last, v)
+		}
+		g.edges[v] = g.edges[last]
+		g.in[v] = g.in[last]
+		if g.sorted != nil {
+			g.sorted[v] = g.sorted[last]
+		}
+	}
+
+	g.edges[last] = nil
+	g.edges = g.edges[:last]
+	g.in[last] = nil
+	g.in = g.in[:last]
+	if g.sorted != nil {
+		g.sorted[last] = nil
+		g.sorted = g.sorted[:last]
+	}
+	g.vlabels.remove(v)
+	return
+}
+
+// InDegree returns the in-degree of vertex v, the number of edges to v.
+// Time complexity: O(1).
+func (g *Hash) InDegree(v int) int {
+	return len(g.in[v])
+}
+
+// DoPredecessors calls action for each vertex u with an edge to v,
+// with x equal to the label of the edge from u to v.
+// The predecessors are visited in random order, even for a sorted graph.
+// Time complexity: O(k), where k is the in-degree of v.
+func (g *Hash) DoPredecessors(v int, action func(u int, x interface{})) {
+	for u := range g.in[v] {
+		action(u, g.edges[u][v])
+	}
+}
+
+// VertexLabel returns the label of vertex v, or nil if v has no label.
+// Time complexity: O(1).
+func (g *Hash) VertexLabel(v int) interface{} {
+	return g.vlabels.get(v)
+}
+
+// SetVertexLabel assigns label x to vertex v; nil removes the label.
+// Time complexity: O(1), except that the first call takes O(n) time,
+// where n is the number of vertices.
+func (g *Hash) SetVertexLabel(v int, x interface{}) {
+	g.vlabels.set(len(g.edges), v, x)
+}
+
+// Copy returns a copy of this graph, with the same vertices, edges,
+// edge and vertex labels, and neighbor order.
+// The labels themselves are not copied.
+// Time complexity: O(n+m), where n and m are the number of
+// vertices and edges.
+func (g *Hash) Copy() *Hash {
+	c := &Hash{
+		edges:    make([]map[int]interface{}, len(g.edges)),
+		in:       make([]map[int]struct{}, len(g.in)),
+		numEdges: g.numEdges,
+	}
+	for v, m := range g.edges {
+		if len(m) == 0 {
+			continue
+		}
+		c.edges[v] = make(map[int]interface{}, len(m))
+		for w, x := range m {
+			c.edges[v][w] = x
+		}
+	}
+	for v, m := range g.in {
+		if len(m) == 0 {
+			continue
+		}
+		c.in[v] = make(map[int]struct{}, len(m))
+		for u := range m {
+			c.in[v][u] = struct{}{}
+		}
+	}
+	if g.sorted != nil {
+		c.sorted = make([][]int, len(g.sorted))
+		for v, a := range g.sorted {
+			c.sorted[v] = append([]int(nil), a...)
+		}
+	}
+	c.vlabels = append(vertexLabels(nil), g.vlabels...)
+	return c
+}
+
+// Subgraph returns the subgraph induced by the vertices vs, which must
+// be distinct. Vertex i in the subgraph corresponds to vertex vs[i] in
+// this graph and has the same label; there is an edge from i to j,
+// with the same label, if there is an edge from vs[i] to vs[j].
+// The subgraph is sorted if this graph is.
+// Time complexity: O(n+m), where n is the number of vertices in this graph
+// and m the number of edges from the vertices vs.
+func (g *Hash) Subgraph(vs []int) *Hash {
+	sub := NewHash(len(vs))
+	if g.sorted != nil {
+		sub = NewSortedHash(len(vs))
+	}
+	index := make([]int, len(g.edges)) // index[v] is the vertex in sub for v, plus one
+	for i, v := range vs {
+		index[v] = i + 1
+	}
+	for i, v := range vs {
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			if j := index[w] - 1; j != -1 {
+				sub.AddLabel(i, j, x)
+			}
+		})
+	}
+	sub.vlabels = g.vlabels.subset(vs)
+	return sub
+}
+
+// insertSorted adds the new neighbor w to the sorted neighbors of v,
+// if the graph is in sorted mode. Time complexity: O(d), where d is
+// the degree of v.
+func (g *Hash) insertSorted(v, w int) {
+	if g.sorted == nil {
+		return
+	}
+	a := g.sorted[v]
+	i := sort.SearchInts(a, w)
+	a = append(a, 0)
+	copy(a[i+1:], a[i:])
+	a[i] = w
+	g.sorted[v] = a
+}
+
+// addIn records the new edge from u to v in the predecessors of v.
+func (g *Hash) addIn(u, v int) {
+	m := g.in[v]
+	if m == nil {
+		m = make(map[int]struct{}, initialMapSize)
+		g.in[v] = m
+	}
+	m[u] = struct{}{}
+}
+
+// removeSorted removes the neighbor w from the sorted neighbors of v,
+// if the graph is in sorted mode. Time complexity: O(d), where d is
+// the degree of v.
+func (g *Hash) removeSorted(v, w int) {
+	if g.sorted == nil {
+		return
+	}
+	a := g.sorted[v]
+	i := sort.SearchInts(a, w)
+	g.sorted[v] = append(a[:i], a[i+1:]...)
+}
@@ -0,0 +1,193 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DecodeOptions configures DecodeHash and DecodeMatrix.
+// Since the space for the vertices is allocated before any edges are
+// read, a short input can claim many vertices; the limit keeps such
+// input from exhausting memory.
+type DecodeOptions struct {
+	// MaxVertices is the largest number of vertices accepted.
+	// If it is zero or negative, the default for the graph type is used:
+	// DefaultMaxHashVertices or DefaultMaxMatrixVertices.
+	MaxVertices int
+}
+
+const (
+	// DefaultMaxHashVertices is the default limit for a Hash,
+	// which then takes up to about 64 MiB before any edges are added.
+	DefaultMaxHashVertices = 1 << 20
+
+	// DefaultMaxMatrixVertices is the default limit for a Matrix,
+	// which takes Θ(n*n) space: 2048 vertices take 64 MiB.
+	DefaultMaxMatrixVertices = 1 << 11
+)
+
+// limit returns the largest number of vertices accepted by o, which may
+// be nil, given the default for the graph type.
+func (o *DecodeOptions) limit(def int) int {
+	if o == nil || o.MaxVertices <= 0 {
+		return def
+	}
+	return o.MaxVertices
+}
+
+// jsonGraph is the JSON representation of a Hash or Matrix.
+// An edge without a label is encoded with the label omitted.
+type jsonGraph struct {
+	Vertices     int           `json:"vertices"`
+	Sorted       bool          `json:"sorted,omitempty"`
+	VertexLabels []interface{} `json:"vertexLabels,omitempty"`
+	Edges        []jsonEdge    `json:"edges"`
+}
+
+type jsonEdge struct {
+	From  int             `json:"from"`
+	To    int             `json:"to"`
+	Label json.RawMessage `json:"label,omitempty"`
+}
+
+// newJSONGraph returns the JSON representation of g.
+func newJSONGraph(g Iterator, vlabels vertexLabels) (*jsonGraph, error) {
+	j := &jsonGraph{Vertices: g.NumVertices(), VertexLabels: vlabels, Edges: []jsonEdge{}}
+	var err error
+	for v := range vertices(g.NumVertices()) {
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			e := jsonEdge{From: v, To: w}
+			if x != NoLabel && err == nil {
+				e.Label, err = json.Marshal(x)
+			}
+			j.Edges = append(j.Edges, e)
+		})
+	}
+	return j, err
+}
+
+// build adds the edges and vertex labels of j to g, which must have
+// j.Vertices vertices.
+func (j *jsonGraph) build(g interface {
+	Add(from, to int)
+	AddLabel(from, to int, x interface{})
+}) (vertexLabels, error) {
+	if j.VertexLabels != nil && len(j.VertexLabels) != j.Vertices {
+		return nil, fmt.Errorf("graph: %d vertex labels for %d vertices", len(j.VertexLabels), j.Vertices)
+	}
+	for _, e := range j.Edges {
+		if e.From < 0 || e.From >= j.Vertices || e.To < 0 || e.To >= j.Vertices {
+			return nil, fmt.Errorf("graph: edge (%d, %d) out of range", e.From, e.To)
+		}
+		if e.Label == nil {
+			g.Add(e.From, e.To)
+			continue
+		}
+		var x interface{}
+		if err := json.Unmarshal(e.Label, &x); err != nil {
+			return nil, err
+		}
+		g.AddLabel(e.From, e.To, x)
+	}
+	return j.VertexLabels, nil
+}
+
+// decode decodes data into j, accepting at most max vertices.
+func (j *jsonGraph) decode(data []byte, max int) error {
+	if err := json.Unmarshal(data, j); err != nil {
+		return err
+	}
+	if j.Vertices < 0 {
+		return fmt.Errorf("graph: negative number of vertices %d", j.Vertices)
+	}
+	if j.Vertices > max {
+		return fmt.Errorf("graph: %d vertices exceeds the limit of %d", j.Vertices, max)
+	}
+	return nil
+}
+
+// MarshalJSON encodes this graph as a JSON object with the number of
+// vertices, the vertex labels, if any, and a list of edges.
+// The labels are encoded with json.Marshal.
+func (g *Hash) MarshalJSON() ([]byte, error) {
+	j, err := newJSONGraph(g, g.vlabels)
+	if err != nil {
+		return nil, err
+	}
+	j.Sorted = g.sorted != nil
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON replaces this graph with one decoded from data,
+// as encoded by MarshalJSON. The labels are decoded into interface{}
+// values, as by json.Unmarshal; for example, numbers become float64.
+// It returns an error if there are more than DefaultMaxHashVertices
+// vertices; use DecodeHash for another limit.
+func (g *Hash) UnmarshalJSON(data []byte) error {
+	h, err := DecodeHash(data, nil)
+	if err != nil {
+		return err
+	}
+	*g = *h
+	return nil
+}
+
+// DecodeHash returns the graph encoded in data by Hash.MarshalJSON,
+// accepting at most opts.MaxVertices vertices. opts may be nil.
+func DecodeHash(data []byte, opts *DecodeOptions) (*Hash, error) {
+	var j jsonGraph
+	if err := j.decode(data, opts.limit(DefaultMaxHashVertices)); err != nil {
+		return nil, err
+	}
+	h := NewHash(j.Vertices)
+	if j.Sorted {
+		h = NewSortedHash(j.Vertices)
+	}
+	vlabels, err := j.build(h)
+	if err != nil {
+		return nil, err
+	}
+	h.vlabels = vlabels
+	return h, nil
+}
+
+// MarshalJSON encodes this graph as a JSON object with the number of
+// vertices, the vertex labels, if any, and a list of edges.
+// The labels are encoded with json.Marshal.
+func (g *Matrix) MarshalJSON() ([]byte, error) {
+	j, err := newJSONGraph(g, g.vlabels)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(j)
+}
+
+// UnmarshalJSON replaces this graph with one decoded from data,
+// as encoded by MarshalJSON. The labels are decoded into interface{}
+// values, as by json.Unmarshal; for example, numbers become float64.
+// It returns an error if there are more than DefaultMaxMatrixVertices
+// vertices; use DecodeMatrix for another limit.
+func (g *Matrix) UnmarshalJSON(data []byte) error {
+	m, err := DecodeMatrix(data, nil)
+	if err != nil {
+		return err
+	}
+	*g = *m
+	return nil
+}
+
+// DecodeMatrix returns the graph encoded in data by Matrix.MarshalJSON,
+// accepting at most opts.MaxVertices vertices. opts may be nil.
+func DecodeMatrix(data []byte, opts *DecodeOptions) (*Matrix, error) {
+	var j jsonGraph
+	if err := j.decode(data, opts.limit(DefaultMaxMatrixVertices)); err != nil {
+		return nil, err
+	}
+	m := NewMatrix(j.Vertices)
+	vlabels, err := j.build(m)
+	if err != nil {
+		return nil, err
+	}
+	m.vlabels = vlabels
+	return m, nil
+}
@@ -0,0 +1,212 @@
+package graph
+
+import "iter"
+
+// Keyed is a directed graph whose vertices are identified by keys
+// of type K, such as host names or package paths. It maintains a mapping
+// between keys and the vertex numbers of an underlying graph.Hash,
+// which grows as new keys appear.
+type Keyed[K comparable] struct {
+	g     *Hash
+	index map[K]int // index[k] is the vertex with key k
+	keys  []K       // keys[v] is the key of vertex v
+}
+
+// NewKeyed constructs a new graph with no vertices.
+func NewKeyed[K comparable]() *Keyed[K] {
+	return &Keyed[K]{g: NewHash(0), index: make(map[K]int)}
+}
+
+// Graph returns the underlying graph, where vertex v has key Key(v),
+// for use with the algorithms in this package. Edges may be changed
+// directly, since k keeps no state about them, but vertices must be
+// added and removed with AddKey and RemoveKey: AddVertex on the Hash
+// gives a vertex without a key, and RemoveVertex renumbers a vertex
+// without updating its key, so Index and Key go out of sync.
+func (k *Keyed[K]) Graph() *Hash {
+	return k.g
+}
+
+// NumVertices returns the number of vertices in this graph.
+// Time complexity: O(1).
+func (k *Keyed[K]) NumVertices() int {
+	return k.g.NumVertices()
+}
+
+// NumEdges returns the number of (directed) edges in this graph.
+// Time complexity: O(1).
+func (k *Keyed[K]) NumEdges() int {
+	return k.g.NumEdges()
+}
+
+// AddKey returns the vertex with the given key,
+// adding a new vertex with no edges if there is none.
+// Time complexity: O(1) amortized.
+func (k *Keyed[K]) AddKey(key K) int {
+	v, ok := k.index[key]
+	if !ok {
+		v = k.g.AddVertex()
+		k.index[key] = v
+		k.keys = append(k.keys, key)
+	}
+	return v
+}
+
+// RemoveKey removes the vertex with the given key, if any,
+// together with all edges to and from it. The vertex numbers of
+// other keys may change. Time complexity: as for Hash.RemoveVertex.
+func (k *Keyed[K]) RemoveKey(key K) {
+	v, ok := k.index[key]
+	if !ok {
+		return
+	}
+	delete(k.index, key)
+	if moved := k.g.RemoveVertex(v); moved != -1 {
+		k.keys[v] = k.keys[moved]
+		k.index[k.keys[v]] = v
+	}
+	k.keys = k.keys[:len(k.keys)-1]
+}
+
+// Index returns the vertex with the given key, and true,
+// or -1 and false if there is no such vertex.
+// Time complexity: O(1).
+func (k *Keyed[K]) Index(key K) (int, bool) {
+	v, ok := k.index[key]
+	if !ok {
+		return -1, false
+	}
+	return v, true
+}
+
+// Key returns the key of vertex v. Time complexity: O(1).
+func (k *Keyed[K]) Key(v int) K {
+	return k.keys[v]
+}
+
+// Degree returns the degree of the vertex with the given key,
+// or 0 if there is no such vertex. Time complexity: O(1).
+func (k *Keyed[K]) Degree(key K) int {
+	v, ok := k.index[key]
+	if !ok {
+		return 0
+	}
+	return k.g.Degree(v)
+}
+
+// DoNeighbors calls action for each neighbor w of the vertex with key v,
+// with x equal to the label of the edge from v to w.
+// Time complexity: O(m), where m is the number of neighbors.
+func (k *Keyed[K]) DoNeighbors(v K, action func(w K, x interface{})) {
+	for w, x := range k.Neighbors(v) {
+		action(w, x)
+	}
+}
+
+// Neighbors returns an iterator over the neighbors w of the vertex
+// with key v, together with the label of the edge from v to w.
+// Time complexity: O(m), where m is the number of neighbors.
+func (k *Keyed[K]) Neighbors(v K) iter.Seq2[K, interface{}] {
+	return func(yield func(K, interface{}) bool) {
+		i, ok := k.index[v]
+		if !ok {
+			return
+		}
+		for w, x := range k.g.Neighbors(i) {
+			if !yield(k.keys[w], x) {
+				return
+			}
+		}
+	}
+}
+
+// HasEdge returns true if there is an edge from v to w.
+// Time complexity: O(1).
+func (k *Keyed[K]) HasEdge(v, w K) bool {
+	i, ok := k.index[v]
+	j, ok2 := k.index[w]
+	return ok && ok2 && k.g.HasEdge(i, j)
+}
+
+// Returns the label for the edge from v to w, NoLabel if the edge has no label,
+// or nil if no such edge exists.
+// Time complexity: O(1).
+func (k *Keyed[K]) Label(v, w K) interface{} {
+	i, ok := k.index[v]
+	j, ok2 := k.index[w]
+	if !ok || !ok2 {
+		return nil
+	}
+	return k.g.Label(i, j)
+}
+
+// Add inserts a directed edge, adding vertices for new keys.
+// It removes any previous label if this edge already exists.
+// Time complexity: O(1) amortized.
+func (k *Keyed[K]) Add(from, to K) {
+	k.g.Add(k.AddKey(from), k.AddKey(to))
+}
+
+// AddLabel inserts a directed edge with label x, adding vertices for new keys.
+// It overwrites any previous label if this edge already exists.
+// Time complexity: O(1) amortized.
+func (k *Keyed[K]) AddLabel(from, to K, x interface{}) {
+	k.g.AddLabel(k.AddKey(from), k.AddKey(to), x)
+}
+
+// AddBi inserts edges between v and w, adding vertices for new keys.
+// It removes any previous labels if these edges already exists.
+// Time complexity: O(1) amortized.
+func (k *Keyed[K]) AddBi(v, w K) {
+	k.g.AddBi(k.AddKey(v), k.AddKey(w))
+}
+
+// AddBiLabel inserts edges with label x between v and w, adding vertices
+// for new keys. It overwrites any previous labels if these edges already exists.
+// Time complexity: O(1) amortized.
+func (k *Keyed[K]) AddBiLabel(v, w K, x interface{}) {
+	k.g.AddBiLabel(k.AddKey(v), k.AddKey(w), x)
+}
+
+// Remove removes an edge. The vertices are kept. Time complexity: O(1).
+func (k *Keyed[K]) Remove(from, to K) {
+	i, ok := k.index[from]
+	j, ok2 := k.index[to]
+	if ok && ok2 {
+		k.g.Remove(i, j)
+	}
+}
+
+// RemoveBi removes all edges between v and w. The vertices are kept.
+// Time complexity: O(1).
+func (k *Keyed[K]) RemoveBi(v, w K) {
+	k.Remove(v, w)
+	k.Remove(w, v)
+}
+
+// BFS returns the keys of the vertices reachable from start
+// in breath-first order, or nil if there is no vertex with key start.
+// Time complexity: O(n+m), where n and m are the number of vertices and edges.
+func (k *Keyed[K]) BFS(start K) []K {
+	return k.traverse(start, BFS)
+}
+
+// DFS returns the keys of the vertices reachable from start
+// in depth-first order, or nil if there is no vertex with key start.
+// Time complexity: O(n+m), where n and m are the number of vertices and edges.
+func (k *Keyed[K]) DFS(start K) []K {
+	return k.traverse(start, DFS)
+}
+
+func (k *Keyed[K]) traverse(start K, search func(Iterator, int, []bool, func(int))) []K {
+	v, ok := k.index[start]
+	if !ok {
+		return nil
+	}
+	var res []K
+	visited := make([]bool, k.g.NumVertices())
+	search(k.g, v, visited, func(w int) {
+		res = append(res, k.keys[w])
+	})
+	return res
+}
@@ -0,0 +1,86 @@
+package graph_test
+
+import (
+	. "."
+	"sort"
+	"testing"
+)
+
+func TestKeyed(t *testing.T) {
+	g := NewKeyed[string]()
+	g.Add("a", "b")
+	g.AddLabel("b", "c", 5)
+	g.AddBi("c", "d")
+	g.AddKey("e")
+
+	if mess, diff := diff(g.NumVertices(), 5); diff {
+		t.Errorf("NumVertices() %s", mess)
+	}
+	if mess, diff := diff(g.NumEdges(), 4); diff {
+		t.Errorf("NumEdges() %s", mess)
+	}
+	if mess, diff := diff(g.Label("b", "c"), 5); diff {
+		t.Errorf(`Label("b", "c") %s`, mess)
+	}
+	if mess, diff := diff(g.Label("b", "x"), nil); diff {
+		t.Errorf(`Label("b", "x") %s`, mess)
+	}
+	if mess, diff := diff(g.HasEdge("d", "c"), true); diff {
+		t.Errorf(`HasEdge("d", "c") %s`, mess)
+	}
+	if mess, diff := diff(g.HasEdge("c", "b"), false); diff {
+		t.Errorf(`HasEdge("c", "b") %s`, mess)
+	}
+	v, ok := g.Index("c")
+	if mess, diff := diff(g.Key(v), "c"); !ok || diff {
+		t.Errorf(`Key(Index("c")) %s`, mess)
+	}
+	if _, ok := g.Index("x"); ok {
+		t.Errorf(`Index("x") found`)
+	}
+
+	if mess, diff := diff(join(g.BFS("a")), "abcd"); diff {
+		t.Errorf(`BFS("a") %s`, mess)
+	}
+	if mess, diff := diff(join(g.DFS("c")), "cd"); diff {
+		t.Errorf(`DFS("c") %s`, mess)
+	}
+	if g.BFS("x") != nil {
+		t.Errorf(`BFS("x") %v; want nil`, g.BFS("x"))
+	}
+
+	g.RemoveKey("a")
+	if mess, diff := diff(g.NumVertices(), 4); diff {
+		t.Errorf("NumVertices() %s", mess)
+	}
+	if mess, diff := diff(g.NumEdges(), 3); diff {
+		t.Errorf("NumEdges() %s", mess)
+	}
+	var keys []string
+	for w := range g.Neighbors("c") {
+		keys = append(keys, w)
+	}
+	if mess, diff := diff(join(keys), "d"); diff {
+		t.Errorf(`Neighbors("c") %s`, mess)
+	}
+	for _, key := range []string{"b", "c", "d", "e"} {
+		v, ok := g.Index(key)
+		if !ok || g.Key(v) != key {
+			t.Errorf("Index(%q) = %d, %v after RemoveKey", key, v, ok)
+		}
+	}
+	if mess, diff := diff(g.Label("b", "c"), 5); diff {
+		t.Errorf(`Label("b", "c") %s`, mess)
+	}
+}
+
+// Sorts and concatenates a list of strings.
+func join(a []string) string {
+	a = append([]string(nil), a...)
+	sort.Strings(a)
+	s := ""
+	for _, x := range a {
+		s += x
+	}
+	return s
+}
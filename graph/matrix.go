@@ -1,5 +1,7 @@
 package graph
 
+import "iter"
+
 type noEdgeType struct{}
 
 var noEdge noEdgeType
@@ -10,6 +12,8 @@ type Matrix struct {
 	// the edge has no label.
 	adj [][]interface{}
 
+	vlabels vertexLabels // the labels of the vertices
+
 	numEdges int // total number of directed edges in the graph
 }
 
@@ -38,7 +42,7 @@ func (g *Matrix) NumEdges() int {
 	return g.numEdges
 }
 
-// Degree returns the degree of vertex v.
+// Degree returns the degree of vertex v, the number of edges from v.
 // Time complexity: O(n), where n is the number of vertices.
 func (g *Matrix) Degree(v int) int {
 	d := 0
@@ -50,6 +54,29 @@ func (g *Matrix) Degree(v int) int {
 	return d
 }
 
+// InDegree returns the in-degree of vertex v, the number of edges to v.
+// Time complexity: O(n), where n is the number of vertices.
+func (g *Matrix) InDegree(v int) int {
+	d := 0
+	for _, row := range g.adj {
+		if row[v] != noEdge {
+			d++
+		}
+	}
+	return d
+}
+
+// DoPredecessors calls action for each vertex u with an edge to v,
+// in increasing order, with x equal to the label of the edge from u to v.
+// Time complexity: O(n), where n is the number of vertices.
+func (g *Matrix) DoPredecessors(v int, action func(u int, x interface{})) {
+	for u, row := range g.adj {
+		if x := row[v]; x != noEdge {
+			action(u, x)
+		}
+	}
+}
+
 // DoNeighbors calls action for each neighbor w of v,
 // with x equal to the label of the edge from v to w.
 // Time complexity: O(n), where n is the number of vertices.
@@ -61,6 +88,38 @@ func (g *Matrix) DoNeighbors(v int, action func(w int, x interface{})) {
 	}
 }
 
+// Neighbors returns an iterator over the neighbors w of v,
+// together with the label of the edge from v to w.
+// Time complexity: O(n), where n is the number of vertices.
+func (g *Matrix) Neighbors(v int) iter.Seq2[int, interface{}] {
+	return func(yield func(int, interface{}) bool) {
+		for w, x := range g.adj[v] {
+			if x != noEdge && !yield(w, x) {
+				return
+			}
+		}
+	}
+}
+
+// Vertices returns an iterator over the vertices 0 to n-1.
+func (g *Matrix) Vertices() iter.Seq[int] {
+	return vertices(len(g.adj))
+}
+
+// Edges returns an iterator over all edges in this graph.
+// Time complexity: O(n*n), where n is the number of vertices.
+func (g *Matrix) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for v, row := range g.adj {
+			for w, x := range row {
+				if x != noEdge && !yield(Edge{v, w, x}) {
+					return
+				}
+			}
+		}
+	}
+}
+
 // HasEdge returns true if there is an edge from v to w.
 // Time complexity: O(1).
 func (g *Matrix) HasEdge(v, w int) bool {
@@ -129,3 +188,101 @@ func (g *Matrix) RemoveBi(v, w int) {
 		g.Remove(w, v)
 	}
 }
+
+// AddVertex adds a new vertex with no edges to this graph
+// and returns its number, which is the old number of vertices.
+// The rows of the matrix grow like slices, by doubling their capacity.
+// Time complexity: O(n) amortized, where n is the number of vertices.
+func (g *Matrix) AddVertex() int {
+	n := len(g.adj)
+	for v, row := range g.adj {
+		g.adj[v] = append(row, noEdge)
+	}
+	c := n + 1
+	if n > 0 {
+		c = cap(g.adj[0]) // grow in step with the other rows
+	}
+	row := make([]interface{}, n+1, c)
+	for w := range row {
+		row[w] = noEdge
+	}
+	g.adj = append(g.adj, row)
+	g.vlabels.add()
+	return n
+}
+
+// RemoveVertex removes vertex v and all edges to and from v.
+// To keep the vertices numbered from 0 to n-2, the last vertex n-1
+// is renumbered v. RemoveVertex returns the old number of the vertex
+// that now has number v, or -1 if v was the last vertex.
+// Time complexity: O(n), where n is the number of vertices.
+func (g *Matrix) RemoveVertex(v int) (moved int) {
+	last := len(g.adj) - 1
+
+	// Remove the edges from v and the edges to v.
+	for w := range g.adj {
+		g.Remove(v, w)
+		g.Remove(w, v)
+	}
+
+	moved = -1
+	if v != last {
+		// Move row and column last to row and column v.
+		moved = last
+		g.adj[v], g.adj[last] = g.adj[last], g.adj[v]
+		for _, row := range g.adj {
+			row[v] = row[last]
+		}
+	}
+	g.adj[last] = nil
+	g.adj = g.adj[:last]
+	for u, row := range g.adj {
+		row[last] = noEdge // don't keep the old label alive
+		g.adj[u] = row[:last]
+	}
+	g.vlabels.remove(v)
+	return
+}
+
+// VertexLabel returns the label of vertex v, or nil if v has no label.
+// Time complexity: O(1).
+func (g *Matrix) VertexLabel(v int) interface{} {
+	return g.vlabels.get(v)
+}
+
+// SetVertexLabel assigns label x to vertex v; nil removes the label.
+// Time complexity: O(1), except that the first call takes O(n) time,
+// where n is the number of vertices.
+func (g *Matrix) SetVertexLabel(v int, x interface{}) {
+	g.vlabels.set(len(g.adj), v, x)
+}
+
+// Copy returns a copy of this graph, with the same vertices, edges,
+// and edge and vertex labels. The labels themselves are not copied.
+// Time complexity: O(n*n), where n is the number of vertices.
+func (g *Matrix) Copy() *Matrix {
+	c := &Matrix{adj: make([][]interface{}, len(g.adj)), numEdges: g.numEdges}
+	for v, row := range g.adj {
+		c.adj[v] = append([]interface{}(nil), row...)
+	}
+	c.vlabels = append(vertexLabels(nil), g.vlabels...)
+	return c
+}
+
+// Subgraph returns the subgraph induced by the vertices vs, which must
+// be distinct. Vertex i in the subgraph corresponds to vertex vs[i] in
+// this graph and has the same label; there is an edge from i to j,
+// with the same label, if there is an edge from vs[i] to vs[j].
+// Time complexity: O(k*k), where k is the number of vertices in vs.
+func (g *Matrix) Subgraph(vs []int) *Matrix {
+	sub := NewMatrix(len(vs))
+	for i, v := range vs {
+		for j, w := range vs {
+			if x := g.adj[v][w]; x != noEdge {
+				sub.AddLabel(i, j, x)
+			}
+		}
+	}
+	sub.vlabels = g.vlabels.subset(vs)
+	return sub
+}
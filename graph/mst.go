@@ -0,0 +1,199 @@
+package graph
+
+import (
+	"context"
+	"math"
+	"sort"
+)
+
+// The minimum spanning forest algorithms below expect an undirected graph,
+// i.e. a graph where every edge has been inserted with AddBi or AddBiLabel.
+// The weight of an edge is computed by calling weight with its label.
+// A disconnected graph gives a forest with one tree per component.
+// The forest is returned as a new graph whose edges are inserted
+// with AddBiLabel, keeping the labels of the original edges,
+// together with the total weight of its edges.
+
+// Kruskal computes a minimum spanning forest of g using Kruskal's algorithm.
+// Time complexity: O(n + m*log m), where n and m are the number of
+// vertices and edges.
+func (g *Hash) Kruskal(weight func(x interface{}) float64) (forest *Hash, total float64) {
+	forest, total, _ = g.KruskalContext(context.Background(), weight)
+	return
+}
+
+// KruskalContext is like Kruskal, but stops with ctx.Err()
+// if ctx is cancelled before the forest is done.
+func (g *Hash) KruskalContext(ctx context.Context, weight func(x interface{}) float64) (forest *Hash, total float64, err error) {
+	forest = NewHash(g.NumVertices())
+	if total, err = kruskal(ctx, g, weight, forest.AddBiLabel); err != nil {
+		return nil, 0, err
+	}
+	return
+}
+
+// Kruskal computes a minimum spanning forest of g using Kruskal's algorithm.
+// Time complexity: O(n*n + m*log m), where n and m are the number of
+// vertices and edges.
+func (g *Matrix) Kruskal(weight func(x interface{}) float64) (forest *Matrix, total float64) {
+	forest, total, _ = g.KruskalContext(context.Background(), weight)
+	return
+}
+
+// KruskalContext is like Kruskal, but stops with ctx.Err()
+// if ctx is cancelled before the forest is done.
+func (g *Matrix) KruskalContext(ctx context.Context, weight func(x interface{}) float64) (forest *Matrix, total float64, err error) {
+	forest = NewMatrix(g.NumVertices())
+	if total, err = kruskal(ctx, g, weight, forest.AddBiLabel); err != nil {
+		return nil, 0, err
+	}
+	return
+}
+
+// Prim computes a minimum spanning forest of g using Prim's algorithm
+// with a binary heap.
+// Time complexity: O((n+m)log n), where n and m are the number of
+// vertices and edges.
+func (g *Hash) Prim(weight func(x interface{}) float64) (forest *Hash, total float64) {
+	forest, total, _ = g.PrimContext(context.Background(), weight)
+	return
+}
+
+// PrimContext is like Prim, but stops with ctx.Err()
+// if ctx is cancelled before the forest is done.
+func (g *Hash) PrimContext(ctx context.Context, weight func(x interface{}) float64) (forest *Hash, total float64, err error) {
+	forest = NewHash(g.NumVertices())
+	n := g.NumVertices()
+	inTree := make([]bool, n)
+	key := make([]float64, n)
+	from := make([]int, n)
+	for v := range key {
+		key[v] = math.Inf(1)
+		from[v] = -1
+	}
+
+	c := &canceller{ctx: ctx}
+	q := &pqueue{}
+	for root := 0; root < n; root++ {
+		if inTree[root] {
+			continue
+		}
+		key[root] = 0
+		q.push(root, 0)
+		for q.Len() > 0 {
+			if err := c.step(); err != nil {
+				return nil, 0, err
+			}
+			v, d := q.pop()
+			if inTree[v] || d > key[v] {
+				continue // stale queue entry
+			}
+			inTree[v] = true
+			if u := from[v]; u != -1 {
+				forest.AddBiLabel(u, v, g.Label(u, v))
+				total += d
+			}
+			g.DoNeighbors(v, func(w int, x interface{}) {
+				if c := weight(x); !inTree[w] && c < key[w] {
+					key[w] = c
+					from[w] = v
+					q.push(w, c)
+				}
+			})
+		}
+	}
+	return
+}
+
+// Prim computes a minimum spanning forest of g using Prim's algorithm
+// with a linear scan for the closest vertex, which is optimal for
+// dense graphs.
+// Time complexity: O(n*n), where n is the number of vertices.
+func (g *Matrix) Prim(weight func(x interface{}) float64) (forest *Matrix, total float64) {
+	forest, total, _ = g.PrimContext(context.Background(), weight)
+	return
+}
+
+// PrimContext is like Prim, but stops with ctx.Err()
+// if ctx is cancelled before the forest is done.
+func (g *Matrix) PrimContext(ctx context.Context, weight func(x interface{}) float64) (forest *Matrix, total float64, err error) {
+	n := g.NumVertices()
+	forest = NewMatrix(n)
+	inTree := make([]bool, n)
+	key := make([]float64, n)
+	from := make([]int, n)
+	for v := range key {
+		key[v] = math.Inf(1)
+		from[v] = -1
+	}
+
+	c := &canceller{ctx: ctx}
+	for i := 0; i < n; i++ {
+		if err := c.step(); err != nil {
+			return nil, 0, err
+		}
+		// Pick the closest vertex not in the forest. If no vertex
+		// is adjacent to the forest, any vertex starts a new tree.
+		v := -1
+		for w, in := range inTree {
+			if !in && (v == -1 || key[w] < key[v]) {
+				v = w
+			}
+		}
+		inTree[v] = true
+		if u := from[v]; u != -1 {
+			forest.AddBiLabel(u, v, g.adj[u][v])
+			total += key[v]
+		}
+		for w, x := range g.adj[v] {
+			if x == noEdge || inTree[w] {
+				continue
+			}
+			if c := weight(x); c < key[w] {
+				key[w] = c
+				from[w] = v
+			}
+		}
+	}
+	return
+}
+
+// kruskal calls add for each edge in a minimum spanning forest of g
+// and returns the total weight of the forest.
+// It stops with ctx.Err() if ctx is cancelled.
+func kruskal(ctx context.Context, g Iterator, weight func(x interface{}) float64, add func(v, w int, x interface{})) (total float64, err error) {
+	c := &canceller{ctx: ctx}
+	type edge struct {
+		v, w int
+		x    interface{}
+		c    float64
+	}
+	var edges []edge
+	n := g.NumVertices()
+	for v := 0; v < n; v++ {
+		if err := c.step(); err != nil {
+			return 0, err
+		}
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			if v != w {
+				edges = append(edges, edge{v, w, x, weight(x)})
+			}
+		})
+	}
+	sort.SliceStable(edges, func(i, j int) bool { return edges[i].c < edges[j].c })
+
+	u := NewUnionFind(n)
+	for _, e := range edges {
+		if u.Count() == 1 {
+			break
+		}
+		if err := c.step(); err != nil {
+			return 0, err
+		}
+		if u.Union(e.v, e.w) {
+			add(e.v, e.w, e.x)
+			total += e.c
+		}
+	}
+	return
+}
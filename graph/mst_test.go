@@ -0,0 +1,83 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestUnionFind(t *testing.T) {
+	u := NewUnionFind(5)
+	if mess, diff := diff(u.Count(), 5); diff {
+		t.Errorf("Count() %s", mess)
+	}
+	if mess, diff := diff(u.Union(0, 1), true); diff {
+		t.Errorf("Union(0, 1) %s", mess)
+	}
+	if mess, diff := diff(u.Union(3, 1), true); diff {
+		t.Errorf("Union(3, 1) %s", mess)
+	}
+	if mess, diff := diff(u.Union(0, 3), false); diff {
+		t.Errorf("Union(0, 3) %s", mess)
+	}
+	if mess, diff := diff(u.Connected(0, 3), true); diff {
+		t.Errorf("Connected(0, 3) %s", mess)
+	}
+	if mess, diff := diff(u.Connected(0, 4), false); diff {
+		t.Errorf("Connected(0, 4) %s", mess)
+	}
+	if mess, diff := diff(u.Size(3), 3); diff {
+		t.Errorf("Size(3) %s", mess)
+	}
+	if mess, diff := diff(u.Count(), 3); diff {
+		t.Errorf("Count() %s", mess)
+	}
+}
+
+func TestMinSpanningForest(t *testing.T) {
+	// Two components: a weighted square with a diagonal, and a single edge.
+	// Vertex 7 is isolated.
+	edges := [][3]int{
+		{0, 1, 1}, {1, 2, 2}, {2, 3, 1}, {3, 0, 4}, {0, 2, 3},
+		{4, 5, 7}, {5, 5, 1},
+	}
+	h := NewHash(8)
+	m := NewMatrix(8)
+	for _, e := range edges {
+		h.AddBiLabel(e[0], e[1], e[2])
+		m.AddBiLabel(e[0], e[1], e[2])
+	}
+
+	type result struct {
+		g     Grapher
+		total float64
+	}
+	results := make(map[string]result)
+	f, total := h.Kruskal(intWeight)
+	results["Hash.Kruskal"] = result{f, total}
+	f, total = h.Prim(intWeight)
+	results["Hash.Prim"] = result{f, total}
+	mf, total := m.Kruskal(intWeight)
+	results["Matrix.Kruskal"] = result{mf, total}
+	mf, total = m.Prim(intWeight)
+	results["Matrix.Prim"] = result{mf, total}
+
+	for impl, r := range results {
+		if mess, diff := diff(r.total, 11.0); diff {
+			t.Errorf("%s: total %s", impl, mess)
+		}
+		if mess, diff := diff(r.g.NumEdges(), 2*4); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		for _, e := range [][3]int{{0, 1, 1}, {1, 2, 2}, {2, 3, 1}, {4, 5, 7}} {
+			if mess, diff := diff(r.g.Label(e[0], e[1]), e[2]); diff {
+				t.Errorf("%s: Label(%d, %d) %s", impl, e[0], e[1], mess)
+			}
+			if mess, diff := diff(r.g.Label(e[1], e[0]), e[2]); diff {
+				t.Errorf("%s: Label(%d, %d) %s", impl, e[1], e[0], mess)
+			}
+		}
+		if mess, diff := diff(r.g.Degree(7), 0); diff {
+			t.Errorf("%s: Degree(7) %s", impl, mess)
+		}
+	}
+}
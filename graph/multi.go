@@ -0,0 +1,173 @@
+package graph
+
+import "iter"
+
+// EdgeID identifies an edge in a Multi graph.
+type EdgeID int
+
+// Multi is a directed multigraph: there may be several edges, each with
+// its own label, from one vertex to another, and edges from a vertex
+// to itself. Every edge is identified by the EdgeID returned when it was
+// added; IDs are never reused, even after the edge has been removed.
+//
+// Multi implements Iterator, with DoNeighbors calling action once
+// for each parallel edge, so it can be used with the algorithms in
+// this package. Algorithms that assume at most one edge between
+// two vertices, such as Floyd-Warshall, are not available.
+type Multi struct {
+	// The map out[v] contains the mapping {w:ids} if there are edges
+	// from v to w; ids lists these edges in the order they were added.
+	// The maps may be nil and are allocated only when needed.
+	out []map[int][]EdgeID
+
+	edges  map[EdgeID]Edge // the edges of the graph
+	degree []int           // degree[v] is the number of edges from v
+	nextID EdgeID          // the ID of the next edge to be added
+}
+
+// NewMulti constructs a new graph with n vertices and no edges.
+func NewMulti(n int) *Multi {
+	return &Multi{
+		out:    make([]map[int][]EdgeID, n),
+		edges:  make(map[EdgeID]Edge),
+		degree: make([]int, n),
+	}
+}
+
+// NumVertices returns the number of vertices in this graph.
+// Time complexity: O(1).
+func (g *Multi) NumVertices() int {
+	return len(g.out)
+}
+
+// NumEdges returns the number of (directed) edges in this graph,
+// counting each parallel edge. Time complexity: O(1).
+func (g *Multi) NumEdges() int {
+	return len(g.edges)
+}
+
+// Degree returns the number of edges from vertex v.
+// Time complexity: O(1).
+func (g *Multi) Degree(v int) int {
+	return g.degree[v]
+}
+
+// DoNeighbors calls action once for each edge from v to w,
+// with x equal to the label of the edge.
+// Time complexity: O(m), where m is the number of edges from v.
+func (g *Multi) DoNeighbors(v int, action func(w int, x interface{})) {
+	for w, ids := range g.out[v] {
+		for _, id := range ids {
+			action(w, g.edges[id].Label)
+		}
+	}
+}
+
+// Neighbors returns an iterator over the edges from v, yielding
+// the ID and the edge. Time complexity: O(m), where m is the number
+// of edges from v.
+func (g *Multi) Neighbors(v int) iter.Seq2[EdgeID, Edge] {
+	return func(yield func(EdgeID, Edge) bool) {
+		for _, ids := range g.out[v] {
+			for _, id := range ids {
+				if !yield(id, g.edges[id]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Edges returns an iterator over all edges in this graph, yielding
+// the ID and the edge. Time complexity: O(n+m), where n and m are
+// the number of vertices and edges.
+func (g *Multi) Edges() iter.Seq2[EdgeID, Edge] {
+	return func(yield func(EdgeID, Edge) bool) {
+		for v := range g.out {
+			for id, e := range g.Neighbors(v) {
+				if !yield(id, e) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Edge returns the edge with the given ID, and true,
+// or the zero Edge and false if there is no such edge.
+// Time complexity: O(1).
+func (g *Multi) Edge(id EdgeID) (Edge, bool) {
+	e, ok := g.edges[id]
+	return e, ok
+}
+
+// HasEdge returns true if there is at least one edge from v to w.
+// Time complexity: O(1).
+func (g *Multi) HasEdge(v, w int) bool {
+	return len(g.out[v][w]) > 0
+}
+
+// EdgesBetween returns the IDs of the edges from v to w,
+// in the order they were added.
+// Time complexity: O(k), where k is the number of such edges.
+func (g *Multi) EdgesBetween(v, w int) []EdgeID {
+	return append([]EdgeID(nil), g.out[v][w]...)
+}
+
+// Add inserts a new directed edge with no label and returns its ID.
+// Time complexity: O(1) amortized.
+func (g *Multi) Add(from, to int) EdgeID {
+	return g.AddLabel(from, to, NoLabel)
+}
+
+// AddLabel inserts a new directed edge with label x and returns its ID.
+// Existing edges from from to to are kept.
+// Time complexity: O(1) amortized.
+func (g *Multi) AddLabel(from, to int, x interface{}) EdgeID {
+	m := g.out[from]
+	if m == nil {
+		m = make(map[int][]EdgeID, initialMapSize)
+		g.out[from] = m
+	}
+	id := g.nextID
+	g.nextID++
+	m[to] = append(m[to], id)
+	g.edges[id] = Edge{From: from, To: to, Label: x}
+	g.degree[from]++
+	return id
+}
+
+// RemoveEdge removes the edge with the given ID, if any.
+// Time complexity: O(k), where k is the number of edges
+// parallel to the removed edge.
+func (g *Multi) RemoveEdge(id EdgeID) {
+	e, ok := g.edges[id]
+	if !ok {
+		return
+	}
+	delete(g.edges, id)
+	g.degree[e.From]--
+	ids := g.out[e.From][e.To]
+	for i, x := range ids {
+		if x == id {
+			ids = append(ids[:i], ids[i+1:]...)
+			break
+		}
+	}
+	if len(ids) == 0 {
+		delete(g.out[e.From], e.To)
+	} else {
+		g.out[e.From][e.To] = ids
+	}
+}
+
+// Remove removes all edges from v to w.
+// Time complexity: O(k), where k is the number of such edges.
+func (g *Multi) Remove(from, to int) {
+	ids := g.out[from][to]
+	for _, id := range ids {
+		delete(g.edges, id)
+	}
+	g.degree[from] -= len(ids)
+	delete(g.out[from], to)
+}
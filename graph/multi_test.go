@@ -0,0 +1,91 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestMulti(t *testing.T) {
+	g := NewMulti(3)
+	a := g.AddLabel(0, 1, 100)
+	b := g.AddLabel(0, 1, 50)
+	c := g.Add(0, 1)
+	loop := g.AddLabel(2, 2, 7)
+	g.AddLabel(2, 2, 8)
+	g.AddLabel(1, 2, 1)
+
+	if mess, diff := diff(g.NumEdges(), 6); diff {
+		t.Errorf("NumEdges() %s", mess)
+	}
+	if mess, diff := diff(g.Degree(0), 3); diff {
+		t.Errorf("Degree(0) %s", mess)
+	}
+	if mess, diff := diff(g.Degree(2), 2); diff {
+		t.Errorf("Degree(2) %s", mess)
+	}
+	if ids := g.EdgesBetween(0, 1); len(ids) != 3 || ids[0] != a || ids[1] != b || ids[2] != c {
+		t.Errorf("EdgesBetween(0, 1) %v; want [%d %d %d]", ids, a, b, c)
+	}
+	if e, ok := g.Edge(loop); !ok || e != (Edge{2, 2, 7}) {
+		t.Errorf("Edge(%d) %v, %v; want {2 2 7}, true", loop, e, ok)
+	}
+
+	sum := 0
+	g.DoNeighbors(0, func(w int, x interface{}) {
+		if x != NoLabel {
+			sum += x.(int)
+		}
+	})
+	if mess, diff := diff(sum, 150); diff {
+		t.Errorf("sum of DoNeighbors(0) labels %s", mess)
+	}
+	dist, _, err := Dijkstra(g, 0, func(x interface{}) float64 {
+		if x == NoLabel {
+			return 1000
+		}
+		return float64(x.(int))
+	})
+	if err != nil {
+		t.Fatalf("Dijkstra error %v", err)
+	}
+	if mess, diff := diff(dist[2], 51.0); diff {
+		t.Errorf("dist[2] %s", mess)
+	}
+
+	g.RemoveEdge(b)
+	g.RemoveEdge(b)
+	if mess, diff := diff(g.NumEdges(), 5); diff {
+		t.Errorf("NumEdges() after RemoveEdge %s", mess)
+	}
+	if _, ok := g.Edge(b); ok {
+		t.Errorf("Edge(%d) found after RemoveEdge", b)
+	}
+	if ids := g.EdgesBetween(0, 1); len(ids) != 2 || ids[0] != a || ids[1] != c {
+		t.Errorf("EdgesBetween(0, 1) %v; want [%d %d]", ids, a, c)
+	}
+	if d := g.Add(0, 1); d == a || d == b || d == c {
+		t.Errorf("Add(0, 1) reused ID %d", d)
+	}
+
+	g.Remove(0, 1)
+	g.RemoveEdge(loop)
+	if mess, diff := diff(g.HasEdge(0, 1), false); diff {
+		t.Errorf("HasEdge(0, 1) %s", mess)
+	}
+	if mess, diff := diff(g.HasEdge(2, 2), true); diff {
+		t.Errorf("HasEdge(2, 2) %s", mess)
+	}
+	if mess, diff := diff(g.Degree(0), 0); diff {
+		t.Errorf("Degree(0) %s", mess)
+	}
+	count := 0
+	for id, e := range g.Edges() {
+		if f, _ := g.Edge(id); f != e {
+			t.Errorf("Edges() yields %d, %v; Edge(%d) %v", id, e, id, f)
+		}
+		count++
+	}
+	if mess, diff := diff(count, g.NumEdges()); diff {
+		t.Errorf("Edges() #it: %s", mess)
+	}
+}
@@ -0,0 +1,172 @@
+package graph
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ParallelOptions configures ParallelBFS.
+type ParallelOptions struct {
+	// Workers is the number of goroutines processing each level.
+	// If it is zero or negative, runtime.GOMAXPROCS(0) is used.
+	Workers int
+
+	// Reverse, if not nil, lists the predecessors of each vertex,
+	// as for BidirectionalBFS. It enables bottom-up steps, where each
+	// unvisited vertex looks for a parent in the frontier, which are
+	// much cheaper than top-down steps when the frontier is large.
+	Reverse Iterator
+}
+
+const (
+	// Size of the pieces into which the work of one level is split.
+	parallelChunk = 256
+
+	// A bottom-up step is used when more than 1/bottomUpFraction
+	// of the vertices are in the frontier.
+	bottomUpFraction = 20
+)
+
+// ParallelBFS computes the same hop distances as BFSTree, but processes
+// each level of the search with a pool of goroutines.
+// dist[v] is the number of edges on a shortest path from the nearest
+// source to v, or -1 if v is not reachable. opts may be nil.
+//
+// g, and opts.Reverse if set, must not be modified during the search,
+// but may be read concurrently; this holds for graph.Hash and graph.Matrix.
+//
+// Time complexity: O((n+m)/p + d) for graph.Hash with p workers
+// in the best case, where n and m are the number of vertices and edges
+// and d is the largest distance.
+func ParallelBFS(g Iterator, sources []int, opts *ParallelOptions) []int {
+	dist, _ := ParallelBFSContext(context.Background(), g, sources, opts)
+	return dist
+}
+
+// ParallelBFSContext is like ParallelBFS, but stops with ctx.Err()
+// if ctx is cancelled before the search is done. The workers check ctx
+// before each chunk of work, so a level is abandoned part way through.
+func ParallelBFSContext(ctx context.Context, g Iterator, sources []int, opts *ParallelOptions) ([]int, error) {
+	var o ParallelOptions
+	if opts != nil {
+		o = *opts
+	}
+	if o.Workers <= 0 {
+		o.Workers = runtime.GOMAXPROCS(0)
+	}
+
+	n := g.NumVertices()
+	dist := make([]int32, n)
+	for v := range dist {
+		dist[v] = -1
+	}
+	var frontier []int
+	for _, v := range sources {
+		if dist[v] == -1 {
+			dist[v] = 0
+			frontier = append(frontier, v)
+		}
+	}
+
+	c := &canceller{ctx: ctx}
+	for level := int32(0); len(frontier) > 0; level++ {
+		if err := c.step(); err != nil {
+			return nil, err
+		}
+		if o.Reverse != nil && len(frontier) > n/bottomUpFraction {
+			frontier = bottomUp(ctx, o.Reverse, dist, level, o.Workers)
+		} else {
+			frontier = topDown(ctx, g, frontier, dist, level, o.Workers)
+		}
+	}
+	// The last level may have been cut short.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	res := make([]int, n)
+	for v, d := range dist {
+		res[v] = int(d)
+	}
+	return res, nil
+}
+
+// topDown visits the neighbors of the frontier at distance level
+// and returns the new frontier.
+func topDown(ctx context.Context, g Iterator, frontier []int, dist []int32, level int32, workers int) []int {
+	return parallelChunks(ctx, len(frontier), workers, func(lo, hi int, next []int) []int {
+		for _, v := range frontier[lo:hi] {
+			g.DoNeighbors(v, func(w int, _ interface{}) {
+				if atomic.LoadInt32(&dist[w]) == -1 &&
+					atomic.CompareAndSwapInt32(&dist[w], -1, level+1) {
+					next = append(next, w)
+				}
+			})
+		}
+		return next
+	})
+}
+
+// bottomUp lets each unvisited vertex look for a predecessor at
+// distance level using rev, and returns the new frontier.
+func bottomUp(ctx context.Context, rev Iterator, dist []int32, level int32, workers int) []int {
+	return parallelChunks(ctx, len(dist), workers, func(lo, hi int, next []int) []int {
+		for v := lo; v < hi; v++ {
+			if atomic.LoadInt32(&dist[v]) != -1 {
+				continue
+			}
+			found := false
+			rev.DoNeighbors(v, func(u int, _ interface{}) {
+				if !found && atomic.LoadInt32(&dist[u]) == level {
+					found = true
+				}
+			})
+			if found {
+				atomic.StoreInt32(&dist[v], level+1)
+				next = append(next, v)
+			}
+		}
+		return next
+	})
+}
+
+// parallelChunks splits the range [0, n) into chunks that are handed out
+// to the workers, and concatenates the slices produced by work.
+// No more chunks are handed out once ctx is cancelled; ctx.Err is
+// safe for concurrent use, unlike a canceller.
+func parallelChunks(ctx context.Context, n, workers int, work func(lo, hi int, next []int) []int) []int {
+	if n <= parallelChunk || workers == 1 {
+		var next []int
+		for lo := 0; lo < n && ctx.Err() == nil; lo += parallelChunk {
+			next = work(lo, min(lo+parallelChunk, n), next)
+		}
+		return next
+	}
+	var (
+		pos  int64 // start of the next chunk to hand out
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		next []int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local []int
+			for {
+				lo := int(atomic.AddInt64(&pos, parallelChunk)) - parallelChunk
+				if lo >= n || ctx.Err() != nil {
+					break
+				}
+				local = work(lo, min(lo+parallelChunk, n), local)
+			}
+			mu.Lock()
+			next = append(next, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return next
+}
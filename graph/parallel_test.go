@@ -0,0 +1,47 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestParallelBFS(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := randomGraph(f, 2000, 6000, 3)
+		rev := Transpose(g)
+		sources := []int{0, 1000}
+		tree := BFSTree(g, sources)
+
+		for name, opts := range map[string]*ParallelOptions{
+			"nil":              nil,
+			"1 worker":         {Workers: 1},
+			"4 workers":        {Workers: 4},
+			"4 workers bottom": {Workers: 4, Reverse: rev},
+		} {
+			dist := ParallelBFS(g, sources, opts)
+			for v, d := range dist {
+				if d != tree.Dist(v) {
+					t.Errorf("%s %s: dist[%d] = %d; want %d", impl, name, v, d, tree.Dist(v))
+					break
+				}
+			}
+		}
+	}
+}
+
+func BenchmarkBFSTree(b *testing.B) {
+	g := randomGraph(func(n int) Grapher { return NewHash(n) }, 100000, 1000000, 1)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		BFSTree(g, []int{0})
+	}
+}
+
+func BenchmarkParallelBFS(b *testing.B) {
+	g := randomGraph(func(n int) Grapher { return NewHash(n) }, 100000, 1000000, 1)
+	opts := &ParallelOptions{Reverse: Transpose(g)}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ParallelBFS(g, []int{0}, opts)
+	}
+}
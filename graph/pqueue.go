@@ -0,0 +1,38 @@
+package graph
+
+import "container/heap"
+
+// pqItem is a vertex together with its priority.
+type pqItem struct {
+	v int
+	d float64
+}
+
+// pqueue is a min-priority queue of vertices ordered by priority.
+// Stale entries are not removed when a priority decreases;
+// callers push a new entry and skip outdated ones when popping.
+type pqueue []pqItem
+
+func (q pqueue) Len() int            { return len(q) }
+func (q pqueue) Less(i, j int) bool  { return q[i].d < q[j].d }
+func (q pqueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *pqueue) Push(x interface{}) { *q = append(*q, x.(pqItem)) }
+
+func (q *pqueue) Pop() interface{} {
+	old := *q
+	n := len(old) - 1
+	x := old[n]
+	*q = old[:n]
+	return x
+}
+
+// push inserts vertex v with priority d.
+func (q *pqueue) push(v int, d float64) {
+	heap.Push(q, pqItem{v, d})
+}
+
+// pop removes and returns the vertex with the smallest priority.
+func (q *pqueue) pop() (v int, d float64) {
+	x := heap.Pop(q).(pqItem)
+	return x.v, x.d
+}
@@ -0,0 +1,82 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+// Implemented by both versions, but not part of Grapher.
+type Predecessorer interface {
+	InDegree(int) int
+	DoPredecessors(int, func(int, interface{}))
+}
+
+// Checks InDegree and DoPredecessors of g against a scan of all edges.
+func checkPredecessors(t *testing.T, impl, when string, g Grapher) {
+	t.Helper()
+	p := g.(Predecessorer)
+	for v := 0; v < g.NumVertices(); v++ {
+		want := make(map[int]interface{})
+		for u := 0; u < g.NumVertices(); u++ {
+			if g.HasEdge(u, v) {
+				want[u] = g.Label(u, v)
+			}
+		}
+		if mess, diff := diff(p.InDegree(v), len(want)); diff {
+			t.Errorf("%s: %s InDegree(%d) %s", impl, when, v, mess)
+		}
+		count := 0
+		p.DoPredecessors(v, func(u int, x interface{}) {
+			if y, ok := want[u]; !ok || x != y {
+				t.Errorf("%s: %s DoPredecessors(%d) yields %d, %v", impl, when, v, u, x)
+			}
+			count++
+		})
+		if mess, diff := diff(count, len(want)); diff {
+			t.Errorf("%s: %s DoPredecessors(%d) #calls: %s", impl, when, v, mess)
+		}
+	}
+}
+
+func TestPredecessors(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := randomGraph(f, 30, 120, 1)
+		g.AddLabel(29, 29, 1)
+		g.AddLabel(3, 29, 2)
+		g.AddLabel(29, 3, 3)
+		checkPredecessors(t, impl, "random", g)
+
+		g.Remove(3, 29)
+		g.RemoveBi(0, 1)
+		checkPredecessors(t, impl, "after Remove", g)
+
+		vs := g.(VertexSetter)
+		vs.RemoveVertex(3)
+		vs.RemoveVertex(7)
+		vs.RemoveVertex(vs.AddVertex())
+		g.Add(g.NumVertices()-1, 0)
+		checkPredecessors(t, impl, "after RemoveVertex", g)
+
+		switch h := g.(type) {
+		case *Hash:
+			g = h.Copy()
+		case *Matrix:
+			g = h.Copy()
+		}
+		g.Add(0, 5)
+		checkPredecessors(t, impl, "Copy", g)
+
+		p := g.(PredecessorIterator)
+		rev := Transpose(g)
+		for v := 0; v < g.NumVertices(); v++ {
+			if mess, diff := diff(len(BFSTree(Reversed(p), []int{v}).Levels()), len(BFSTree(rev, []int{v}).Levels())); diff {
+				t.Errorf("%s: BFS levels from %d in Reversed(g) %s", impl, v, mess)
+			}
+			for w := 0; w < g.NumVertices(); w += 5 {
+				if mess, diff := diff(len(BidirectionalBFS(g, Reversed(p), v, w)), len(FindPath(g, v, w))); diff {
+					t.Errorf("%s: BidirectionalBFS(g, Reversed(g), %d, %d) length %s", impl, v, w, mess)
+				}
+			}
+		}
+	}
+}
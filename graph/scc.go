@@ -0,0 +1,210 @@
+package graph
+
+import "context"
+
+// Transpose returns a new graph with the same vertices as g and
+// an edge from w to v, with the same label, for each edge from v to w in g.
+// Time complexity: O(n+m) if g is a graph.Hash and O(n*n) if it's a graph.Matrix.
+func Transpose(g Iterator) *Hash {
+	t, _ := transpose(&canceller{ctx: context.Background()}, g)
+	return t
+}
+
+// transpose is like Transpose, but stops with an error
+// if the context of c is cancelled.
+func transpose(c *canceller, g Iterator) (*Hash, error) {
+	n := g.NumVertices()
+	t := NewHash(n)
+	for v := 0; v < n; v++ {
+		if err := c.step(); err != nil {
+			return nil, err
+		}
+		g.DoNeighbors(v, func(w int, x interface{}) {
+			t.AddLabel(w, v, x)
+		})
+	}
+	return t, nil
+}
+
+// TarjanSCC computes the strongly connected components of g
+// using Tarjan's algorithm.
+//
+// comp[v] is the component of vertex v, and components[i] lists
+// the vertices of component i. The components are numbered in reverse
+// topological order: if there is an edge from component i to
+// component j, then i > j.
+//
+// The depth-first search is iterative, so deep graphs
+// cannot overflow the call stack.
+//
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func TarjanSCC(g Iterator) (comp []int, components [][]int) {
+	comp, components, _ = TarjanSCCContext(context.Background(), g)
+	return
+}
+
+// TarjanSCCContext is like TarjanSCC, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func TarjanSCCContext(ctx context.Context, g Iterator) (comp []int, components [][]int, err error) {
+	n := g.NumVertices()
+	c := &canceller{ctx: ctx}
+	comp = make([]int, n)
+	index := make([]int, n) // index[v] is the preorder number of v, starting at 1
+	low := make([]int, n)   // lowest preorder number reachable from v within the DFS tree
+	onStack := make([]bool, n)
+	for v := range comp {
+		comp[v] = -1
+	}
+
+	type frame struct {
+		v    int
+		adj  []int // neighbors of v
+		next int   // next neighbor to examine
+	}
+	var frames []frame
+	var stack []int
+	count := 0
+	push := func(v int) {
+		count++
+		index[v], low[v] = count, count
+		stack = append(stack, v)
+		onStack[v] = true
+		frames = append(frames, frame{v: v, adj: neighbors(g, v)})
+	}
+
+	for s := 0; s < n; s++ {
+		if index[s] != 0 {
+			continue
+		}
+		push(s)
+		for len(frames) > 0 {
+			if err := c.step(); err != nil {
+				return nil, nil, err
+			}
+			f := &frames[len(frames)-1]
+			if f.next < len(f.adj) {
+				w := f.adj[f.next]
+				f.next++
+				if index[w] == 0 {
+					push(w)
+				} else if onStack[w] && index[w] < low[f.v] {
+					low[f.v] = index[w]
+				}
+				continue
+			}
+
+			// All neighbors of v are done.
+			v := f.v
+			frames = frames[:len(frames)-1]
+			if len(frames) > 0 {
+				if u := frames[len(frames)-1].v; low[v] < low[u] {
+					low[u] = low[v]
+				}
+			}
+			if low[v] != index[v] {
+				continue
+			}
+			// v is the root of a component; pop it off the stack.
+			i := len(components)
+			var members []int
+			for {
+				w := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[w] = false
+				comp[w] = i
+				members = append(members, w)
+				if w == v {
+					break
+				}
+			}
+			components = append(components, members)
+		}
+	}
+	return
+}
+
+// KosarajuSCC computes the strongly connected components of g
+// using Kosaraju's algorithm, which runs one depth-first search on g
+// and one on the transpose of g. If g implements PredecessorIterator,
+// as graph.Hash and graph.Matrix do, the second search uses Reversed(g);
+// otherwise it runs on a copy made by Transpose.
+//
+// comp[v] is the component of vertex v, and components[i] lists
+// the vertices of component i. The components are numbered in
+// topological order: if there is an edge from component i to
+// component j, then i < j.
+//
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func KosarajuSCC(g Iterator) (comp []int, components [][]int) {
+	comp, components, _ = KosarajuSCCContext(context.Background(), g)
+	return
+}
+
+// KosarajuSCCContext is like KosarajuSCC, but stops with ctx.Err()
+// if ctx is cancelled before the searches are done.
+func KosarajuSCCContext(ctx context.Context, g Iterator) (comp []int, components [][]int, err error) {
+	n := g.NumVertices()
+	order, err := postorder(ctx, g)
+	if err != nil {
+		return nil, nil, err
+	}
+	c := &canceller{ctx: ctx}
+	var t Iterator
+	if p, ok := g.(PredecessorIterator); ok {
+		t = Reversed(p)
+	} else if t, err = transpose(c, g); err != nil {
+		return nil, nil, err
+	}
+
+	comp = make([]int, n)
+	visited := make([]bool, n)
+	for i := n - 1; i >= 0; i-- {
+		v := order[i]
+		if visited[v] {
+			continue
+		}
+		j := len(components)
+		var members []int
+		stopped := DFSUntil(t, v, visited, func(w int) bool {
+			if c.step() != nil {
+				return true
+			}
+			comp[w] = j
+			members = append(members, w)
+			return false
+		})
+		if stopped {
+			return nil, nil, c.err
+		}
+		components = append(components, members)
+	}
+	return
+}
+
+// postorder returns the vertices of g in the order in which
+// a depth-first search of the whole graph finishes them.
+func postorder(ctx context.Context, g Iterator) ([]int, error) {
+	p := &postorderVisitor{order: make([]int, 0, g.NumVertices())}
+	if _, _, err := DepthFirstContext(ctx, g, p); err != nil {
+		return nil, err
+	}
+	return p.order, nil
+}
+
+type postorderVisitor struct {
+	NopVisitor
+	order []int
+}
+
+func (p *postorderVisitor) FinishVertex(v int) { p.order = append(p.order, v) }
+
+// neighbors returns the neighbors of v in g.
+func neighbors(g Iterator, v int) []int {
+	var adj []int
+	g.DoNeighbors(v, func(w int, _ interface{}) {
+		adj = append(adj, w)
+	})
+	return adj
+}
@@ -0,0 +1,112 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestTranspose(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(3)
+		g.AddLabel(0, 1, 5)
+		g.Add(1, 2)
+		g.Add(2, 2)
+
+		tr := Transpose(g)
+		if mess, diff := diff(tr.NumEdges(), 3); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		if mess, diff := diff(tr.Label(1, 0), 5); diff {
+			t.Errorf("%s: Label(1, 0) %s", impl, mess)
+		}
+		if mess, diff := diff(tr.HasEdge(2, 1), true); diff {
+			t.Errorf("%s: HasEdge(2, 1) %s", impl, mess)
+		}
+		if mess, diff := diff(tr.HasEdge(1, 2), false); diff {
+			t.Errorf("%s: HasEdge(1, 2) %s", impl, mess)
+		}
+		if mess, diff := diff(tr.HasEdge(2, 2), true); diff {
+			t.Errorf("%s: HasEdge(2, 2) %s", impl, mess)
+		}
+	}
+}
+
+func TestSCC(t *testing.T) {
+	algs := map[string]func(Iterator) ([]int, [][]int){
+		"Tarjan":   TarjanSCC,
+		"Kosaraju": KosarajuSCC,
+	}
+	for impl, f := range AlgoFuncs {
+		g := f(8)
+		edges := [][2]int{
+			{0, 1}, {1, 2}, {2, 0}, // {0, 1, 2}
+			{2, 3}, {3, 4}, {4, 3}, // {3, 4}
+			{4, 5},                 // {5}
+			{6, 7}, {7, 6}, {6, 6}, // {6, 7}
+		}
+		for _, e := range edges {
+			g.Add(e[0], e[1])
+		}
+		for alg, scc := range algs {
+			comp, components := scc(g)
+			if mess, diff := diff(len(components), 4); diff {
+				t.Errorf("%s %s: len(components) %s", impl, alg, mess)
+			}
+			same := [][]int{{0, 1, 2}, {3, 4}, {5}, {6, 7}}
+			for _, s := range same {
+				for _, v := range s {
+					if comp[v] != comp[s[0]] {
+						t.Errorf("%s %s: comp[%d] = %d, comp[%d] = %d; want equal",
+							impl, alg, v, comp[v], s[0], comp[s[0]])
+					}
+				}
+				if mess, diff := diff(len(components[comp[s[0]]]), len(s)); diff {
+					t.Errorf("%s %s: len(components[comp[%d]]) %s", impl, alg, s[0], mess)
+				}
+			}
+			if comp[0] == comp[3] || comp[3] == comp[5] || comp[0] == comp[6] {
+				t.Errorf("%s %s: comp %v; want distinct components", impl, alg, comp)
+			}
+			// The edge {2, 3} goes from the component of 0 to that of 3.
+			if alg == "Tarjan" && comp[0] < comp[3] || alg == "Kosaraju" && comp[0] > comp[3] {
+				t.Errorf("%s %s: comp[0] = %d, comp[3] = %d; wrong order", impl, alg, comp[0], comp[3])
+			}
+		}
+	}
+}
+
+func TestSCCDeep(t *testing.T) {
+	const n = 200000
+	g := NewHash(n)
+	for v := 0; v+1 < n; v++ {
+		g.Add(v, v+1)
+	}
+	g.Add(n-1, 0)
+	if _, components := TarjanSCC(g); len(components) != 1 {
+		t.Errorf("Tarjan: len(components) = %d; want 1", len(components))
+	}
+	if _, components := KosarajuSCC(g); len(components) != 1 {
+		t.Errorf("Kosaraju: len(components) = %d; want 1", len(components))
+	}
+}
+
+// Kosaraju uses Reversed for Hash and Matrix, and a transposed copy
+// for other graphs, such as CSR; both must give the same components.
+func TestKosarajuTranspose(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := randomGraph(f, 100, 150, 1)
+		comp, _ := KosarajuSCC(g)
+		comp2, components2 := KosarajuSCC(Freeze(g))
+		// The numbering may differ, but the partition must not.
+		same := make(map[int]int)
+		for v, c := range comp {
+			if c2, ok := same[c]; ok && c2 != comp2[v] {
+				t.Errorf("%s: vertex %d in component %d; want %d", impl, v, comp2[v], c2)
+			}
+			same[c] = comp2[v]
+		}
+		if mess, diff := diff(len(same), len(components2)); diff {
+			t.Errorf("%s: number of components %s", impl, mess)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+)
+
+// CycleError is returned when a graph that must be acyclic has a cycle.
+type CycleError struct {
+	// Cycle lists the vertices of a cycle in order: there is an edge
+	// from Cycle[i] to Cycle[i+1] and from the last vertex to Cycle[0].
+	Cycle []int
+}
+
+func (e *CycleError) Error() string {
+	return fmt.Sprintf("graph: cycle %v", e.Cycle)
+}
+
+// TopoSort returns the vertices of g in topological order, such that
+// for each edge from v to w, v comes before w. It uses Kahn's algorithm.
+// If g has a cycle, TopoSort returns a *CycleError holding one cycle.
+//
+// When several vertices could come next, the order depends on the order
+// in which DoNeighbors lists neighbors; use LexTopoSort for a
+// reproducible order.
+//
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func TopoSort(g Iterator) ([]int, error) {
+	return TopoSortContext(context.Background(), g)
+}
+
+// TopoSortContext is like TopoSort, but stops with ctx.Err()
+// if ctx is cancelled before the sort is done.
+func TopoSortContext(ctx context.Context, g Iterator) ([]int, error) {
+	var queue []int
+	return kahn(&canceller{ctx: ctx}, g,
+		func(v int) { queue = append(queue, v) },
+		func() (v int, ok bool) {
+			if len(queue) == 0 {
+				return 0, false
+			}
+			v, queue = queue[0], queue[1:]
+			return v, true
+		})
+}
+
+// LexTopoSort is like TopoSort, but returns the lexicographically
+// smallest topological order: when several vertices could come next,
+// the smallest one is chosen. The result does not depend on the order
+// of neighbors, so it is the same for graph.Hash and graph.Matrix.
+// Time complexity: O((n+m)log n) for graph.Hash and O(n*n) for
+// graph.Matrix, where n and m are the number of vertices and edges.
+func LexTopoSort(g Iterator) ([]int, error) {
+	return LexTopoSortContext(context.Background(), g)
+}
+
+// LexTopoSortContext is like LexTopoSort, but stops with ctx.Err()
+// if ctx is cancelled before the sort is done.
+func LexTopoSortContext(ctx context.Context, g Iterator) ([]int, error) {
+	q := &pqueue{}
+	return kahn(&canceller{ctx: ctx}, g,
+		func(v int) { q.push(v, float64(v)) }, // order by vertex number
+		func() (v int, ok bool) {
+			if q.Len() == 0 {
+				return 0, false
+			}
+			v, _ = q.pop()
+			return v, true
+		})
+}
+
+// kahn implements Kahn's algorithm; push and pop manage
+// the set of vertices whose predecessors have all been output.
+// It stops with an error if the context of c is cancelled.
+func kahn(c *canceller, g Iterator, push func(v int), pop func() (int, bool)) ([]int, error) {
+	n := g.NumVertices()
+	indegree := make([]int, n)
+	for v := 0; v < n; v++ {
+		if err := c.step(); err != nil {
+			return nil, err
+		}
+		g.DoNeighbors(v, func(w int, _ interface{}) {
+			indegree[w]++
+		})
+	}
+	for v, d := range indegree {
+		if d == 0 {
+			push(v)
+		}
+	}
+
+	order := make([]int, 0, n)
+	for {
+		v, ok := pop()
+		if !ok {
+			break
+		}
+		if err := c.step(); err != nil {
+			return nil, err
+		}
+		order = append(order, v)
+		g.DoNeighbors(v, func(w int, _ interface{}) {
+			indegree[w]--
+			if indegree[w] == 0 {
+				push(w)
+			}
+		})
+	}
+	if len(order) < n {
+		return nil, &CycleError{Cycle: findCycle(g, indegree)}
+	}
+	return order, nil
+}
+
+// findCycle returns a cycle among the vertices with positive indegree
+// left after Kahn's algorithm. Each such vertex has a predecessor that
+// is also left, so walking backwards must eventually repeat a vertex.
+func findCycle(g Iterator, indegree []int) []int {
+	n := g.NumVertices()
+	pred := make([]int, n)
+	start := -1
+	for v := 0; v < n; v++ {
+		if indegree[v] == 0 {
+			continue
+		}
+		start = v
+		g.DoNeighbors(v, func(w int, _ interface{}) {
+			if indegree[w] > 0 {
+				pred[w] = v
+			}
+		})
+	}
+
+	// Walk backwards until a vertex repeats.
+	seen := make([]bool, n)
+	v := start
+	for !seen[v] {
+		seen[v] = true
+		v = pred[v]
+	}
+	cycle := []int{v}
+	for w := pred[v]; w != v; w = pred[w] {
+		cycle = append(cycle, w)
+	}
+	// The cycle was collected backwards; reverse it to follow edge direction.
+	for i, j := 0, len(cycle)-1; i < j; i, j = i+1, j-1 {
+		cycle[i], cycle[j] = cycle[j], cycle[i]
+	}
+	return cycle
+}
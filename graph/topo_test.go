@@ -0,0 +1,73 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+func TestTopoSort(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(6)
+		edges := [][2]int{{5, 2}, {5, 0}, {4, 0}, {4, 1}, {2, 3}, {3, 1}}
+		for _, e := range edges {
+			g.Add(e[0], e[1])
+		}
+
+		order, err := TopoSort(g)
+		if err != nil {
+			t.Fatalf("%s: TopoSort error %v", impl, err)
+		}
+		pos := make([]int, len(order))
+		for i, v := range order {
+			pos[v] = i
+		}
+		for _, e := range edges {
+			if pos[e[0]] > pos[e[1]] {
+				t.Errorf("%s: TopoSort %v; %d after %d", impl, order, e[0], e[1])
+			}
+		}
+
+		order, err = LexTopoSort(g)
+		if err != nil {
+			t.Fatalf("%s: LexTopoSort error %v", impl, err)
+		}
+		if mess, diff := diff(order, []int{4, 5, 0, 2, 3, 1}); diff {
+			t.Errorf("%s: LexTopoSort %s", impl, mess)
+		}
+
+		g.Add(1, 5)
+		for alg, sort := range map[string]func(Iterator) ([]int, error){
+			"TopoSort":    TopoSort,
+			"LexTopoSort": LexTopoSort,
+		} {
+			_, err := sort(g)
+			c, ok := err.(*CycleError)
+			if !ok {
+				t.Fatalf("%s: %s error %v; want *CycleError", impl, alg, err)
+			}
+			if !isRotation(c.Cycle, []int{1, 5, 2, 3}) {
+				t.Errorf("%s: %s cycle %v; want rotation of [1 5 2 3]", impl, alg, c.Cycle)
+			}
+		}
+
+		g.Add(0, 0)
+		_, err = LexTopoSort(g)
+		if c, ok := err.(*CycleError); !ok || len(c.Cycle) == 0 {
+			t.Errorf("%s: LexTopoSort error %v; want *CycleError", impl, err)
+		}
+	}
+}
+
+// Checks if a is a rotation of b.
+func isRotation(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k := range b {
+		rotated := append(append([]int{}, b[k:]...), b[:k]...)
+		if arrayEq(a, rotated) {
+			return true
+		}
+	}
+	return false
+}
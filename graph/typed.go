@@ -0,0 +1,302 @@
+package graph
+
+import "iter"
+
+// HashOf is like graph.Hash, but every edge has a label of type L.
+// Edges added without a label get the zero value of L.
+//
+// HashOf implements Iterator, with DoNeighbors passing labels
+// as interface{} values of dynamic type L, so it can be used with
+// all algorithms in this package. The shortest-path algorithms have
+// typed entry points, such as DijkstraOf, that take a weight function
+// for labels of type L; for the others, use Weight.
+type HashOf[L any] struct {
+	// The map edges[v] contains the mapping {w:x} if there is an edge
+	// from v to w; x is the label assigned to this edge.
+	// The maps may be nil and are allocated only when needed.
+	edges []map[int]L
+
+	numEdges int // total number of directed edges in the graph
+}
+
+// NewHashOf constructs a new graph with n vertices and no edges.
+func NewHashOf[L any](n int) *HashOf[L] {
+	return &HashOf[L]{edges: make([]map[int]L, n)}
+}
+
+// NumVertices returns the number of vertices in this graph.
+// Time complexity: O(1).
+func (g *HashOf[L]) NumVertices() int {
+	return len(g.edges)
+}
+
+// NumEdges returns the number of (directed) edges in this graph.
+// Time complexity: O(1).
+func (g *HashOf[L]) NumEdges() int {
+	return g.numEdges
+}
+
+// Degree returns the degree of vertex v. Time complexity: O(1).
+func (g *HashOf[L]) Degree(v int) int {
+	return len(g.edges[v])
+}
+
+// DoNeighbors calls action for each neighbor w of v,
+// with x equal to the label of the edge from v to w.
+// Time complexity: O(m), where m is the number of neighbors.
+func (g *HashOf[L]) DoNeighbors(v int, action func(w int, x interface{})) {
+	for w, x := range g.edges[v] {
+		action(w, x)
+	}
+}
+
+// Neighbors returns an iterator over the neighbors w of v,
+// together with the label of the edge from v to w.
+// Time complexity: O(m), where m is the number of neighbors.
+func (g *HashOf[L]) Neighbors(v int) iter.Seq2[int, L] {
+	return func(yield func(int, L) bool) {
+		for w, x := range g.edges[v] {
+			if !yield(w, x) {
+				return
+			}
+		}
+	}
+}
+
+// HasEdge returns true if there is an edge from v to w.
+// Time complexity: O(1).
+func (g *HashOf[L]) HasEdge(v, w int) bool {
+	_, ok := g.edges[v][w]
+	return ok
+}
+
+// Label returns the label of the edge from v to w, and true,
+// or the zero value and false if no such edge exists.
+// Time complexity: O(1).
+func (g *HashOf[L]) Label(v, w int) (x L, ok bool) {
+	x, ok = g.edges[v][w]
+	return
+}
+
+// Add inserts a directed edge with the zero label.
+// It removes any previous label if this edge already exists.
+// Time complexity: O(1).
+func (g *HashOf[L]) Add(from, to int) {
+	var zero L
+	g.AddLabel(from, to, zero)
+}
+
+// AddLabel inserts a directed edge with label x.
+// It overwrites any previous label if this edge already exists.
+// Time complexity: O(1).
+func (g *HashOf[L]) AddLabel(from, to int, x L) {
+	m := g.edges[from]
+	if m == nil {
+		m = make(map[int]L, initialMapSize)
+		g.edges[from] = m
+	}
+	if _, ok := m[to]; !ok {
+		g.numEdges++
+	}
+	m[to] = x
+}
+
+// AddBi inserts edges with the zero label between v and w.
+// It removes any previous labels if these edges already exists.
+// Time complexity: O(1).
+func (g *HashOf[L]) AddBi(v, w int) {
+	var zero L
+	g.AddBiLabel(v, w, zero)
+}
+
+// AddBiLabel inserts edges with label x between v and w.
+// It overwrites any previous labels if these edges already exists.
+// Time complexity: O(1).
+func (g *HashOf[L]) AddBiLabel(v, w int, x L) {
+	g.AddLabel(v, w, x)
+	g.AddLabel(w, v, x)
+}
+
+// Remove removes an edge. Time complexity: O(1).
+func (g *HashOf[L]) Remove(from, to int) {
+	if _, ok := g.edges[from][to]; ok {
+		g.numEdges--
+		delete(g.edges[from], to)
+	}
+}
+
+// RemoveBi removes all edges between v and w. Time complexity: O(1).
+func (g *HashOf[L]) RemoveBi(v, w int) {
+	g.Remove(v, w)
+	g.Remove(w, v)
+}
+
+// MatrixOf is like graph.Matrix, but every edge has a label of type L.
+// Edges added without a label get the zero value of L.
+//
+// Like HashOf, MatrixOf implements Iterator.
+type MatrixOf[L any] struct {
+	// The edges are kept in a bit matrix, and the label of the edge
+	// from v to w is labels[v][w]. Labels of missing edges are zero.
+	edges  *BitMatrix
+	labels [][]L
+}
+
+// NewMatrixOf constructs a new graph with n vertices and no edges.
+func NewMatrixOf[L any](n int) *MatrixOf[L] {
+	labels := make([][]L, n)
+	for v := range labels {
+		labels[v] = make([]L, n)
+	}
+	return &MatrixOf[L]{edges: NewBitMatrix(n), labels: labels}
+}
+
+// NumVertices returns the number of vertices in this graph.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) NumVertices() int {
+	return g.edges.NumVertices()
+}
+
+// NumEdges returns the number of (directed) edges in this graph.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) NumEdges() int {
+	return g.edges.NumEdges()
+}
+
+// Degree returns the degree of vertex v.
+// Time complexity: O(n/64), where n is the number of vertices.
+func (g *MatrixOf[L]) Degree(v int) int {
+	return g.edges.Degree(v)
+}
+
+// DoNeighbors calls action for each neighbor w of v, in increasing order,
+// with x equal to the label of the edge from v to w.
+// Time complexity: O(n/64 + d), where n is the number of vertices
+// and d is the degree of v.
+func (g *MatrixOf[L]) DoNeighbors(v int, action func(w int, x interface{})) {
+	row := g.labels[v]
+	g.edges.DoNeighbors(v, func(w int, _ interface{}) {
+		action(w, row[w])
+	})
+}
+
+// Neighbors returns an iterator over the neighbors w of v, in increasing
+// order, together with the label of the edge from v to w.
+// Time complexity: O(n/64 + d), where n is the number of vertices
+// and d is the degree of v.
+func (g *MatrixOf[L]) Neighbors(v int) iter.Seq2[int, L] {
+	return func(yield func(int, L) bool) {
+		row := g.labels[v]
+		for w := range g.edges.Neighbors(v) {
+			if !yield(w, row[w]) {
+				return
+			}
+		}
+	}
+}
+
+// HasEdge returns true if there is an edge from v to w.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) HasEdge(v, w int) bool {
+	return g.edges.HasEdge(v, w)
+}
+
+// Label returns the label of the edge from v to w, and true,
+// or the zero value and false if no such edge exists.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) Label(v, w int) (x L, ok bool) {
+	return g.labels[v][w], g.edges.HasEdge(v, w)
+}
+
+// Add inserts a directed edge with the zero label.
+// It removes any previous label if this edge already exists.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) Add(from, to int) {
+	var zero L
+	g.AddLabel(from, to, zero)
+}
+
+// AddLabel inserts a directed edge with label x.
+// It overwrites any previous label if this edge already exists.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) AddLabel(from, to int, x L) {
+	g.edges.Add(from, to)
+	g.labels[from][to] = x
+}
+
+// AddBi inserts edges with the zero label between v and w.
+// It removes any previous labels if these edges already exists.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) AddBi(v, w int) {
+	var zero L
+	g.AddBiLabel(v, w, zero)
+}
+
+// AddBiLabel inserts edges with label x between v and w.
+// It overwrites any previous labels if these edges already exists.
+// Time complexity: O(1).
+func (g *MatrixOf[L]) AddBiLabel(v, w int, x L) {
+	g.AddLabel(v, w, x)
+	g.AddLabel(w, v, x)
+}
+
+// Remove removes an edge. Time complexity: O(1).
+func (g *MatrixOf[L]) Remove(from, to int) {
+	var zero L
+	g.edges.Remove(from, to)
+	g.labels[from][to] = zero // don't keep the old label alive
+}
+
+// RemoveBi removes all edges between v and w. Time complexity: O(1).
+func (g *MatrixOf[L]) RemoveBi(v, w int) {
+	g.Remove(v, w)
+	g.Remove(w, v)
+}
+
+// Weight converts a weight function for labels of type L into one that
+// can be passed to the algorithms in this package, such as Dijkstra.
+// A label that is not of type L is passed as the zero L. This includes
+// the nil label that HashOf[L] and MatrixOf[L] store for an edge added
+// without a label when L is an interface type. The label type is not
+// checked by the compiler; prefer DijkstraOf and the other typed entry
+// points below, which take a graph with labels of type L.
+func Weight[L any](weight func(x L) float64) func(x interface{}) float64 {
+	return func(x interface{}) float64 {
+		l, _ := x.(L)
+		return weight(l)
+	}
+}
+
+// TypedIterator is an Iterator whose edge labels all have type L,
+// such as HashOf[L] and MatrixOf[L].
+type TypedIterator[L any] interface {
+	Iterator
+
+	// Neighbors returns an iterator over the neighbors w of v,
+	// together with the label of the edge from v to w.
+	Neighbors(v int) iter.Seq2[int, L]
+}
+
+// DijkstraOf is like Dijkstra, but for a graph with labels of type L.
+// The compiler checks that weight takes labels of the graph's label type.
+func DijkstraOf[L any](g TypedIterator[L], s int, weight func(x L) float64) (dist []float64, parent []int, err error) {
+	return Dijkstra(g, s, Weight(weight))
+}
+
+// BellmanFordOf is like BellmanFord, but for a graph with labels of type L.
+// The compiler checks that weight takes labels of the graph's label type.
+func BellmanFordOf[L any](g TypedIterator[L], s int, weight func(x L) float64) (dist []float64, parent []int, cycle []int) {
+	return BellmanFord(g, s, Weight(weight))
+}
+
+// AStarOf is like AStar, but for a graph with labels of type L.
+// The compiler checks that weight takes labels of the graph's label type.
+func AStarOf[L any](g TypedIterator[L], src, dst int, weight func(x L) float64, h func(v int) float64) (path []int, cost float64, stats SearchStats, err error) {
+	return AStar(g, src, dst, Weight(weight), h)
+}
+
+// JohnsonOf is like Johnson, but for a graph with labels of type L.
+// The compiler checks that weight takes labels of the graph's label type.
+func JohnsonOf[L any](g TypedIterator[L], weight func(x L) float64) (*AllPairs, error) {
+	return Johnson(g, Weight(weight))
+}
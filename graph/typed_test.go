@@ -0,0 +1,117 @@
+package graph_test
+
+import (
+	. "."
+	"iter"
+	"testing"
+)
+
+type TypedGrapher[L any] interface {
+	NumVertices() int
+	NumEdges() int
+	Degree(int) int
+	DoNeighbors(int, func(int, interface{}))
+	Neighbors(int) iter.Seq2[int, L]
+	HasEdge(int, int) bool
+	Label(int, int) (L, bool)
+	Add(int, int)
+	AddLabel(int, int, L)
+	AddBi(int, int)
+	AddBiLabel(int, int, L)
+	Remove(int, int)
+	RemoveBi(int, int)
+}
+
+var TypedFuncs = map[string]func(int) TypedGrapher[float64]{
+	"HashOf":   func(n int) TypedGrapher[float64] { return NewHashOf[float64](n) },
+	"MatrixOf": func(n int) TypedGrapher[float64] { return NewMatrixOf[float64](n) },
+}
+
+func TestTyped(t *testing.T) {
+	for impl, f := range TypedFuncs {
+		g := f(4)
+		g.AddLabel(0, 1, 1.5)
+		g.AddLabel(1, 2, 2.5)
+		g.AddBiLabel(0, 3, 10)
+		g.Add(3, 3)
+
+		if mess, diff := diff(g.NumEdges(), 5); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		if mess, diff := diff(g.Degree(0), 2); diff {
+			t.Errorf("%s: Degree(0) %s", impl, mess)
+		}
+		if x, ok := g.Label(0, 1); x != 1.5 || !ok {
+			t.Errorf("%s: Label(0, 1) %v, %v; want 1.5, true", impl, x, ok)
+		}
+		if x, ok := g.Label(3, 3); x != 0 || !ok {
+			t.Errorf("%s: Label(3, 3) %v, %v; want 0, true", impl, x, ok)
+		}
+		if x, ok := g.Label(1, 0); x != 0 || ok {
+			t.Errorf("%s: Label(1, 0) %v, %v; want 0, false", impl, x, ok)
+		}
+
+		sum := 0.0
+		for _, x := range g.Neighbors(0) {
+			sum += x
+		}
+		if mess, diff := diff(sum, 11.5); diff {
+			t.Errorf("%s: sum of Neighbors(0) labels %s", impl, mess)
+		}
+
+		id := func(x float64) float64 { return x }
+		dist, _, err := Dijkstra(g, 0, Weight(id))
+		if err != nil {
+			t.Fatalf("%s: Dijkstra error %v", impl, err)
+		}
+		if mess, diff := diff(dist[2], 4.0); diff {
+			t.Errorf("%s: dist[2] %s", impl, mess)
+		}
+		dist, _, err = DijkstraOf(g, 0, id)
+		if err != nil {
+			t.Fatalf("%s: DijkstraOf error %v", impl, err)
+		}
+		if mess, diff := diff(dist[2], 4.0); diff {
+			t.Errorf("%s: DijkstraOf dist[2] %s", impl, mess)
+		}
+		dist, _, cycle := BellmanFordOf(g, 0, id)
+		if mess, diff := diff(dist[2], 4.0); diff || cycle != nil {
+			t.Errorf("%s: BellmanFordOf dist[2] %s, cycle %v", impl, mess, cycle)
+		}
+		zero := func(int) float64 { return 0 }
+		if _, cost, _, err := AStarOf(g, 0, 2, id, zero); err != nil || cost != 4 {
+			t.Errorf("%s: AStarOf cost %v, error %v; want 4, nil", impl, cost, err)
+		}
+		if p, err := JohnsonOf(g, id); err != nil || p.Dist(3, 2) != 14 {
+			t.Errorf("%s: JohnsonOf error %v", impl, err)
+		}
+
+		g.RemoveBi(0, 3)
+		g.Remove(0, 2)
+		if mess, diff := diff(g.NumEdges(), 3); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		if x, ok := g.Label(3, 0); x != 0 || ok {
+			t.Errorf("%s: Label(3, 0) %v, %v; want 0, false", impl, x, ok)
+		}
+	}
+}
+
+func TestTypedInterfaceLabels(t *testing.T) {
+	g := NewHashOf[any](3)
+	g.Add(0, 1)
+	g.AddLabel(1, 2, 2.5)
+	weight := func(x any) float64 {
+		if x == nil {
+			return 1
+		}
+		return x.(float64)
+	}
+	dist, _, err := DijkstraOf(g, 0, weight)
+	if err != nil {
+		t.Fatalf("DijkstraOf error %v", err)
+	}
+	if mess, diff := diff(dist[2], 3.5); diff {
+		t.Errorf("DijkstraOf dist[2] %s", mess)
+	}
+}
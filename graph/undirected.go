@@ -0,0 +1,138 @@
+package graph
+
+import "iter"
+
+// Undirected is an undirected graph, backed by a directed Hash or Matrix
+// in which each edge {v, w} is stored in both directions, with the same
+// label. Add and Remove always update both directions.
+//
+// NumEdges counts each undirected edge, including a self-loop, once.
+// Degree follows the usual convention that a self-loop {v, v} adds two
+// to the degree of v.
+type Undirected struct {
+	g     directed
+	loops int // number of self-loops
+}
+
+// directed is the part of Hash and Matrix used by Undirected.
+type directed interface {
+	Iterator
+	NumEdges() int
+	Degree(v int) int
+	HasEdge(v, w int) bool
+	Label(v, w int) interface{}
+	AddLabel(from, to int, x interface{})
+	Remove(from, to int)
+}
+
+// NewUndirectedHash constructs a new undirected graph with n vertices
+// and no edges, backed by a Hash.
+func NewUndirectedHash(n int) *Undirected {
+	return &Undirected{g: NewHash(n)}
+}
+
+// NewUndirectedMatrix constructs a new undirected graph with n vertices
+// and no edges, backed by a Matrix.
+func NewUndirectedMatrix(n int) *Undirected {
+	return &Undirected{g: NewMatrix(n)}
+}
+
+// Graph returns the underlying directed graph, a *Hash or a *Matrix,
+// with an edge in each direction for every undirected edge, for use
+// with the algorithms in this package. Editing it directly can leave
+// an edge in one direction only, so that HasEdge(v, w) and HasEdge(w, v)
+// disagree, and doesn't update u's count of self-loops, so NumEdges
+// and Degree may then be wrong.
+func (u *Undirected) Graph() Iterator {
+	return u.g
+}
+
+// NumVertices returns the number of vertices in this graph.
+// Time complexity: O(1).
+func (u *Undirected) NumVertices() int {
+	return u.g.NumVertices()
+}
+
+// NumEdges returns the number of (undirected) edges in this graph.
+// Time complexity: O(1).
+func (u *Undirected) NumEdges() int {
+	return (u.g.NumEdges() + u.loops) / 2
+}
+
+// Degree returns the degree of vertex v, where a self-loop counts twice.
+// Time complexity: as for the underlying graph.
+func (u *Undirected) Degree(v int) int {
+	d := u.g.Degree(v)
+	if u.g.HasEdge(v, v) {
+		d++
+	}
+	return d
+}
+
+// DoNeighbors calls action for each neighbor w of v,
+// with x equal to the label of the edge between v and w.
+// A self-loop makes v a neighbor of itself, visited once.
+// Time complexity: as for the underlying graph.
+func (u *Undirected) DoNeighbors(v int, action func(w int, x interface{})) {
+	u.g.DoNeighbors(v, action)
+}
+
+// Edges returns an iterator over all edges {v, w} in this graph,
+// each yielded once as an Edge with From <= To.
+// Time complexity: as for calling DoNeighbors on every vertex.
+func (u *Undirected) Edges() iter.Seq[Edge] {
+	return func(yield func(Edge) bool) {
+		for v := range vertices(u.g.NumVertices()) {
+			stop := false
+			u.g.DoNeighbors(v, func(w int, x interface{}) {
+				if !stop && v <= w {
+					stop = !yield(Edge{v, w, x})
+				}
+			})
+			if stop {
+				return
+			}
+		}
+	}
+}
+
+// HasEdge returns true if there is an edge between v and w.
+// Time complexity: O(1).
+func (u *Undirected) HasEdge(v, w int) bool {
+	return u.g.HasEdge(v, w)
+}
+
+// Label returns the label of the edge between v and w, NoLabel if the edge
+// has no label, or nil if no such edge exists.
+// Time complexity: O(1).
+func (u *Undirected) Label(v, w int) interface{} {
+	return u.g.Label(v, w)
+}
+
+// Add inserts an edge between v and w.
+// It removes any previous label if this edge already exists.
+// Time complexity: as for the underlying graph.
+func (u *Undirected) Add(v, w int) {
+	u.AddLabel(v, w, NoLabel)
+}
+
+// AddLabel inserts an edge with label x between v and w.
+// It overwrites any previous label if this edge already exists.
+// Time complexity: as for the underlying graph.
+func (u *Undirected) AddLabel(v, w int, x interface{}) {
+	if v == w && !u.g.HasEdge(v, v) {
+		u.loops++
+	}
+	u.g.AddLabel(v, w, x)
+	u.g.AddLabel(w, v, x)
+}
+
+// Remove removes the edge between v and w, if any.
+// Time complexity: as for the underlying graph.
+func (u *Undirected) Remove(v, w int) {
+	if v == w && u.g.HasEdge(v, v) {
+		u.loops--
+	}
+	u.g.Remove(v, w)
+	u.g.Remove(w, v)
+}
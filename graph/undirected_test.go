@@ -0,0 +1,70 @@
+package graph_test
+
+import (
+	. "."
+	"testing"
+)
+
+var UndirectedFuncs = map[string]func(int) *Undirected{
+	"Hash":   NewUndirectedHash,
+	"Matrix": NewUndirectedMatrix,
+}
+
+func TestUndirected(t *testing.T) {
+	for impl, f := range UndirectedFuncs {
+		g := f(4)
+		g.Add(0, 1)
+		g.AddLabel(2, 1, 5)
+		g.Add(3, 3)
+		g.Add(3, 3)
+		g.AddLabel(1, 0, 7)
+
+		if mess, diff := diff(g.NumEdges(), 3); diff {
+			t.Errorf("%s: NumEdges() %s", impl, mess)
+		}
+		for v, d := range []int{1, 2, 1, 2} {
+			if mess, diff := diff(g.Degree(v), d); diff {
+				t.Errorf("%s: Degree(%d) %s", impl, v, mess)
+			}
+		}
+		if mess, diff := diff(g.Label(0, 1), 7); diff {
+			t.Errorf("%s: Label(0, 1) %s", impl, mess)
+		}
+		if mess, diff := diff(g.Label(1, 2), 5); diff {
+			t.Errorf("%s: Label(1, 2) %s", impl, mess)
+		}
+		if mess, diff := diff(g.HasEdge(1, 2), true); diff {
+			t.Errorf("%s: HasEdge(1, 2) %s", impl, mess)
+		}
+
+		count := 0
+		for e := range g.Edges() {
+			if e.From > e.To || g.Label(e.From, e.To) != e.Label {
+				t.Errorf("%s: Edges() yields %v", impl, e)
+			}
+			count++
+		}
+		if mess, diff := diff(count, g.NumEdges()); diff {
+			t.Errorf("%s: Edges() #it: %s", impl, mess)
+		}
+		if mess, diff := diff(FindPath(g, 0, 2), []int{0, 1, 2}); diff {
+			t.Errorf("%s: FindPath(g, 0, 2) %s", impl, mess)
+		}
+
+		g.Remove(1, 0)
+		g.Remove(3, 3)
+		g.Remove(3, 3)
+		if mess, diff := diff(g.NumEdges(), 1); diff {
+			t.Errorf("%s: NumEdges() after Remove %s", impl, mess)
+		}
+		if mess, diff := diff(g.HasEdge(0, 1), false); diff {
+			t.Errorf("%s: HasEdge(0, 1) %s", impl, mess)
+		}
+		if mess, diff := diff(g.Degree(3), 0); diff {
+			t.Errorf("%s: Degree(3) %s", impl, mess)
+		}
+		if mess, diff := diff(g.Graph().(Grapher).NumEdges(), 2); diff {
+			t.Errorf("%s: Graph().NumEdges() %s", impl, mess)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package graph
+
+// UnionFind is a disjoint-set data structure for the elements 0 to n-1.
+// It uses union by size and path halving, which makes the amortized
+// time of each operation nearly constant.
+type UnionFind struct {
+	parent []int // parent[v] is the parent of v, or v itself if v is a root
+	size   []int // size[r] is the number of elements in the set with root r
+	count  int   // number of sets
+}
+
+// NewUnionFind constructs n singleton sets {0}, {1}, ..., {n-1}.
+func NewUnionFind(n int) *UnionFind {
+	u := &UnionFind{parent: make([]int, n), size: make([]int, n), count: n}
+	for v := range u.parent {
+		u.parent[v] = v
+		u.size[v] = 1
+	}
+	return u
+}
+
+// Find returns the representative element of the set containing v.
+func (u *UnionFind) Find(v int) int {
+	for u.parent[v] != v {
+		u.parent[v] = u.parent[u.parent[v]]
+		v = u.parent[v]
+	}
+	return v
+}
+
+// Union merges the sets containing v and w.
+// It returns false if they already were in the same set.
+func (u *UnionFind) Union(v, w int) bool {
+	v, w = u.Find(v), u.Find(w)
+	if v == w {
+		return false
+	}
+	if u.size[v] < u.size[w] {
+		v, w = w, v
+	}
+	u.parent[w] = v
+	u.size[v] += u.size[w]
+	u.count--
+	return true
+}
+
+// Connected returns true if v and w are in the same set.
+func (u *UnionFind) Connected(v, w int) bool {
+	return u.Find(v) == u.Find(w)
+}
+
+// Size returns the number of elements in the set containing v.
+func (u *UnionFind) Size(v int) int {
+	return u.size[u.Find(v)]
+}
+
+// Count returns the number of sets.
+func (u *UnionFind) Count() int {
+	return u.count
+}
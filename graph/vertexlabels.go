@@ -0,0 +1,55 @@
+package graph
+
+// vertexLabels holds the vertex labels of a graph: the label of vertex v
+// is l[v], or nil if v has no label. The slice is nil until the first label
+// is set, so graphs without vertex labels don't pay for them.
+type vertexLabels []interface{}
+
+// get returns the label of v, or nil if v has no label.
+func (l vertexLabels) get(v int) interface{} {
+	if l == nil {
+		return nil
+	}
+	return l[v]
+}
+
+// set assigns label x to v in a graph with n vertices.
+func (l *vertexLabels) set(n, v int, x interface{}) {
+	if *l == nil {
+		if x == nil {
+			return
+		}
+		*l = make(vertexLabels, n)
+	}
+	(*l)[v] = x
+}
+
+// add makes room for the label of a new last vertex.
+func (l *vertexLabels) add() {
+	if *l != nil {
+		*l = append(*l, nil)
+	}
+}
+
+// remove deletes the label of v, and moves the label of the last vertex to v.
+func (l *vertexLabels) remove(v int) {
+	if *l == nil {
+		return
+	}
+	last := len(*l) - 1
+	(*l)[v] = (*l)[last]
+	(*l)[last] = nil
+	*l = (*l)[:last]
+}
+
+// subset returns the labels of the vertices vs, in order.
+func (l vertexLabels) subset(vs []int) vertexLabels {
+	if l == nil {
+		return nil
+	}
+	s := make(vertexLabels, len(vs))
+	for i, v := range vs {
+		s[i] = l[v]
+	}
+	return s
+}
@@ -0,0 +1,140 @@
+package graph_test
+
+import (
+	. "."
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+// Implemented by both versions, but not part of Grapher.
+type VertexLabeler interface {
+	VertexLabel(int) interface{}
+	SetVertexLabel(int, interface{})
+}
+
+// Returns a copy of g, the subgraph induced by vs, and g decoded from JSON.
+func derived(g Grapher, vs []int) (cp, sub, dec Grapher, err error) {
+	data, err := json.Marshal(g)
+	if err != nil {
+		return
+	}
+	switch g := g.(type) {
+	case *Hash:
+		h := new(Hash)
+		err = json.Unmarshal(data, h)
+		return g.Copy(), g.Subgraph(vs), h, err
+	case *Matrix:
+		m := new(Matrix)
+		err = json.Unmarshal(data, m)
+		return g.Copy(), g.Subgraph(vs), m, err
+	}
+	panic("unknown graph type")
+}
+
+func TestVertexLabel(t *testing.T) {
+	for impl, f := range AlgoFuncs {
+		g := f(4)
+		vl := g.(VertexLabeler)
+		if mess, diff := diff(vl.VertexLabel(2), nil); diff {
+			t.Errorf("%s: VertexLabel(2) %s", impl, mess)
+		}
+		vl.SetVertexLabel(0, "a")
+		vl.SetVertexLabel(2, "c")
+		vl.SetVertexLabel(3, "d")
+		g.Add(0, 2)
+		g.AddLabel(2, 3, "x")
+		g.AddLabel(3, 0, nil)
+		g.Add(1, 3)
+
+		cp, sub, dec, err := derived(g, []int{3, 2, 0})
+		if err != nil {
+			t.Fatalf("%s: %v", impl, err)
+		}
+		g.AddLabel(2, 3, "y")
+		vl.SetVertexLabel(2, "changed")
+
+		for name, h := range map[string]Grapher{"Copy": cp, "JSON": dec} {
+			if mess, diff := diff(h.NumEdges(), 4); diff {
+				t.Errorf("%s: %s NumEdges() %s", impl, name, mess)
+			}
+			for v, x := range []interface{}{"a", nil, "c", "d"} {
+				if mess, diff := diff(h.(VertexLabeler).VertexLabel(v), x); diff {
+					t.Errorf("%s: %s VertexLabel(%d) %s", impl, name, v, mess)
+				}
+			}
+			for _, e := range []Edge{{0, 2, NoLabel}, {2, 3, "x"}, {3, 0, nil}, {1, 3, NoLabel}} {
+				if mess, diff := diff(h.Label(e.From, e.To), e.Label); diff {
+					t.Errorf("%s: %s Label(%d, %d) %s", impl, name, e.From, e.To, mess)
+				}
+			}
+		}
+
+		if mess, diff := diff(sub.NumVertices(), 3); diff {
+			t.Errorf("%s: Subgraph NumVertices() %s", impl, mess)
+		}
+		if mess, diff := diff(sub.NumEdges(), 3); diff {
+			t.Errorf("%s: Subgraph NumEdges() %s", impl, mess)
+		}
+		for v, x := range []interface{}{"d", "c", "a"} {
+			if mess, diff := diff(sub.(VertexLabeler).VertexLabel(v), x); diff {
+				t.Errorf("%s: Subgraph VertexLabel(%d) %s", impl, v, mess)
+			}
+		}
+		if mess, diff := diff(sub.Label(1, 0), "x"); diff {
+			t.Errorf("%s: Subgraph Label(1, 0) %s", impl, mess)
+		}
+		if mess, diff := diff(sub.HasEdge(2, 1), true); diff {
+			t.Errorf("%s: Subgraph HasEdge(2, 1) %s", impl, mess)
+		}
+
+		// Vertex 3 becomes vertex 1.
+		g.(VertexSetter).RemoveVertex(1)
+		if mess, diff := diff(vl.VertexLabel(1), "d"); diff {
+			t.Errorf("%s: VertexLabel(1) after RemoveVertex %s", impl, mess)
+		}
+		v := g.(VertexSetter).AddVertex()
+		if mess, diff := diff(vl.VertexLabel(v), nil); diff {
+			t.Errorf("%s: VertexLabel(%d) after AddVertex %s", impl, v, mess)
+		}
+	}
+}
+
+func TestUnmarshalJSONError(t *testing.T) {
+	for _, data := range []string{
+		`{"vertices": 2, "edges": [{"from": 0, "to": 2}]}`,
+		`{"vertices": 2, "vertexLabels": ["a"], "edges": []}`,
+		`{"vertices": -1, "edges": []}`,
+		`{"vertices": 100000000000000, "edges": []}`,
+		`{"vertices": 2, "edges": [{"from": 0, "to": 1, "label": }]}`,
+	} {
+		if err := json.Unmarshal([]byte(data), new(Hash)); err == nil {
+			t.Errorf("Hash: Unmarshal(%s) no error", data)
+		}
+		if err := json.Unmarshal([]byte(data), new(Matrix)); err == nil {
+			t.Errorf("Matrix: Unmarshal(%s) no error", data)
+		}
+	}
+
+	data := fmt.Sprintf(`{"vertices": %d, "edges": []}`, DefaultMaxHashVertices+1)
+	if err := json.Unmarshal([]byte(data), new(Hash)); err == nil {
+		t.Errorf("Hash: Unmarshal(%s) no error", data)
+	}
+	data = fmt.Sprintf(`{"vertices": %d, "edges": []}`, DefaultMaxMatrixVertices+1)
+	if err := json.Unmarshal([]byte(data), new(Matrix)); err == nil {
+		t.Errorf("Matrix: Unmarshal(%s) no error", data)
+	}
+}
+
+func TestDecodeOptions(t *testing.T) {
+	opts := &DecodeOptions{MaxVertices: 10}
+	for n, ok := range map[int]bool{10: true, 11: false} {
+		data := []byte(fmt.Sprintf(`{"vertices": %d, "edges": []}`, n))
+		if h, err := DecodeHash(data, opts); (err == nil) != ok || ok && h.NumVertices() != n {
+			t.Errorf("DecodeHash with %d vertices: %v, %v", n, h, err)
+		}
+		if m, err := DecodeMatrix(data, opts); (err == nil) != ok || ok && m.NumVertices() != n {
+			t.Errorf("DecodeMatrix with %d vertices: %v, %v", n, m, err)
+		}
+	}
+}
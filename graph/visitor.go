@@ -0,0 +1,174 @@
+package graph
+
+import "context"
+
+// Visitor receives events from a depth-first search by DepthFirst
+// or DepthFirstFrom. Edges are classified as in a directed graph;
+// in a graph built with AddBi, the reverse of each tree edge is
+// reported as a back edge.
+type Visitor interface {
+	// DiscoverVertex is called when v is reached for the first time.
+	DiscoverVertex(v int)
+
+	// ExamineEdge is called for each edge from v to w, with label x,
+	// before the edge is classified.
+	ExamineEdge(v, w int, x interface{})
+
+	// TreeEdge is called for an edge to an undiscovered vertex w,
+	// which then becomes a child of v in the search tree.
+	TreeEdge(v, w int, x interface{})
+
+	// BackEdge is called for an edge to an ancestor w of v,
+	// including a loop from v to itself.
+	BackEdge(v, w int, x interface{})
+
+	// ForwardOrCrossEdge is called for an edge to a finished vertex w,
+	// either a descendant of v or a vertex in another branch or tree.
+	ForwardOrCrossEdge(v, w int, x interface{})
+
+	// FinishVertex is called when all edges from v have been examined.
+	FinishVertex(v int)
+}
+
+// NopVisitor is a Visitor that does nothing. Embed it in a struct
+// to implement Visitor by defining only the methods you need.
+type NopVisitor struct{}
+
+func (NopVisitor) DiscoverVertex(v int)                       {}
+func (NopVisitor) ExamineEdge(v, w int, x interface{})        {}
+func (NopVisitor) TreeEdge(v, w int, x interface{})           {}
+func (NopVisitor) BackEdge(v, w int, x interface{})           {}
+func (NopVisitor) ForwardOrCrossEdge(v, w int, x interface{}) {}
+func (NopVisitor) FinishVertex(v int)                         {}
+
+// DepthFirst performs a depth-first search of all of g, starting new
+// search trees at the undiscovered vertices in increasing order,
+// and reports each event to vis.
+//
+// discover[v] and finish[v] are the times at which v was discovered
+// and finished, taken from a single clock that starts at 0 and ticks
+// at each event. Hence v is a descendant of u in the search forest
+// if and only if discover[u] <= discover[v] < finish[v] <= finish[u].
+//
+// The search is iterative, so deep graphs cannot overflow the call stack.
+// Time complexity: O(n+m) for graph.Hash and O(n*n) for graph.Matrix,
+// where n and m are the number of vertices and edges.
+func DepthFirst(g Iterator, vis Visitor) (discover, finish []int) {
+	discover, finish, _ = DepthFirstContext(context.Background(), g, vis)
+	return
+}
+
+// DepthFirstContext is like DepthFirst, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func DepthFirstContext(ctx context.Context, g Iterator, vis Visitor) (discover, finish []int, err error) {
+	d := newDepthFirst(ctx, g, vis)
+	for v := range d.discover {
+		if err := d.search(v); err != nil {
+			return nil, nil, err
+		}
+	}
+	return d.discover, d.finish, nil
+}
+
+// DepthFirstFrom is like DepthFirst, but only searches the vertices
+// reachable from s. The times of other vertices are -1.
+func DepthFirstFrom(g Iterator, s int, vis Visitor) (discover, finish []int) {
+	discover, finish, _ = DepthFirstFromContext(context.Background(), g, s, vis)
+	return
+}
+
+// DepthFirstFromContext is like DepthFirstFrom, but stops with ctx.Err()
+// if ctx is cancelled before the search is done.
+func DepthFirstFromContext(ctx context.Context, g Iterator, s int, vis Visitor) (discover, finish []int, err error) {
+	d := newDepthFirst(ctx, g, vis)
+	if err := d.search(s); err != nil {
+		return nil, nil, err
+	}
+	return d.discover, d.finish, nil
+}
+
+// depthFirst holds the state of a depth-first search.
+type depthFirst struct {
+	g        Iterator
+	vis      Visitor
+	discover []int
+	finish   []int
+	time     int
+	c        *canceller
+}
+
+func newDepthFirst(ctx context.Context, g Iterator, vis Visitor) *depthFirst {
+	n := g.NumVertices()
+	d := &depthFirst{
+		g:        g,
+		vis:      vis,
+		discover: make([]int, n),
+		finish:   make([]int, n),
+		c:        &canceller{ctx: ctx},
+	}
+	for v := range d.discover {
+		d.discover[v] = -1
+		d.finish[v] = -1
+	}
+	return d
+}
+
+// search builds the search tree rooted at s, if s is undiscovered.
+// It stops with an error if the context is cancelled.
+func (d *depthFirst) search(s int) error {
+	if d.discover[s] != -1 {
+		return nil
+	}
+
+	type edge struct {
+		w int
+		x interface{}
+	}
+	type frame struct {
+		v    int
+		out  []edge // edges leaving v
+		next int    // next edge to examine
+	}
+	var stack []frame
+	discover := func(v int) {
+		d.discover[v] = d.time
+		d.time++
+		d.vis.DiscoverVertex(v)
+		f := frame{v: v}
+		d.g.DoNeighbors(v, func(w int, x interface{}) {
+			f.out = append(f.out, edge{w, x})
+		})
+		stack = append(stack, f)
+	}
+
+	if err := d.c.step(); err != nil {
+		return err
+	}
+	discover(s)
+	for len(stack) > 0 {
+		if err := d.c.step(); err != nil {
+			return err
+		}
+		f := &stack[len(stack)-1]
+		if f.next == len(f.out) {
+			d.finish[f.v] = d.time
+			d.time++
+			d.vis.FinishVertex(f.v)
+			stack = stack[:len(stack)-1]
+			continue
+		}
+		v, e := f.v, f.out[f.next]
+		f.next++
+		d.vis.ExamineEdge(v, e.w, e.x)
+		switch {
+		case d.discover[e.w] == -1:
+			d.vis.TreeEdge(v, e.w, e.x)
+			discover(e.w)
+		case d.finish[e.w] == -1:
+			d.vis.BackEdge(v, e.w, e.x)
+		default:
+			d.vis.ForwardOrCrossEdge(v, e.w, e.x)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package graph_test
+
+import (
+	. "."
+	"fmt"
+	"testing"
+)
+
+// Records every event as a string.
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) add(format string, a ...interface{}) {
+	r.events = append(r.events, fmt.Sprintf(format, a...))
+}
+
+func (r *recorder) DiscoverVertex(v int)                       { r.add("d%d", v) }
+func (r *recorder) ExamineEdge(v, w int, x interface{})        {}
+func (r *recorder) TreeEdge(v, w int, x interface{})           { r.add("t%d%d", v, w) }
+func (r *recorder) BackEdge(v, w int, x interface{})           { r.add("b%d%d", v, w) }
+func (r *recorder) ForwardOrCrossEdge(v, w int, x interface{}) { r.add("c%d%d", v, w) }
+func (r *recorder) FinishVertex(v int)                         { r.add("f%d", v) }
+
+func TestDepthFirst(t *testing.T) {
+	g := NewMatrix(5)
+	g.Add(0, 1)
+	g.Add(1, 2)
+	g.Add(2, 0) // back
+	g.Add(0, 2) // forward
+	g.Add(3, 1) // cross
+	g.Add(4, 4) // loop
+
+	r := &recorder{}
+	discover, finish := DepthFirst(g, r)
+	exp := "[d0 t01 d1 t12 d2 b20 f2 f1 c02 f0 d3 c31 f3 d4 b44 f4]"
+	if mess, diff := diff(fmt.Sprint(r.events), exp); diff {
+		t.Errorf("events %s", mess)
+	}
+	if mess, diff := diff(discover, []int{0, 1, 2, 6, 8}); diff {
+		t.Errorf("discover %s", mess)
+	}
+	if mess, diff := diff(finish, []int{5, 4, 3, 7, 9}); diff {
+		t.Errorf("finish %s", mess)
+	}
+
+	discover, finish = DepthFirstFrom(g, 1, NopVisitor{})
+	if mess, diff := diff(discover, []int{2, 0, 1, -1, -1}); diff {
+		t.Errorf("DepthFirstFrom discover %s", mess)
+	}
+	if mess, diff := diff(finish, []int{3, 5, 4, -1, -1}); diff {
+		t.Errorf("DepthFirstFrom finish %s", mess)
+	}
+}
+
+// Reports whether a graph has a cycle.
+type cycleFinder struct {
+	NopVisitor
+	found bool
+}
+
+func (c *cycleFinder) BackEdge(v, w int, x interface{}) { c.found = true }
+
+func ExampleDepthFirst() {
+	g := NewHash(3)
+	g.Add(0, 1)
+	g.Add(1, 2)
+
+	c := &cycleFinder{}
+	DepthFirst(g, c)
+	fmt.Println(c.found)
+
+	g.Add(2, 0)
+	c = &cycleFinder{}
+	DepthFirst(g, c)
+	fmt.Println(c.found)
+	// Output:
+	// false
+	// true
+}
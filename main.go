@@ -39,46 +39,25 @@ type Grapher interface {
 	This function need a Grapher interface instance.
 
 	This function returns the size of the largest component in the graph and the number of components
-	in that graph.
+	in that graph. The edges are directed, so components are counted in two ways:
+	weakly connected (direction ignored) and strongly connected.
 */
 
-func getLargestSizeAndNumOfComponents(g Grapher) (largest_component_size, number_of_components int) {
+func getLargestSizeAndNumOfComponents(g Grapher) (largest_weak_size, number_of_weak, largest_strong_size, number_of_strong int) {
 
-	//we'll be searching for largest component - set it to lowest possible number first
-	largest_component_size = 0
-	number_of_components = 0
+	//let the graph package compute both kinds of components
+	weak := graph.WeakComponents(g)
+	strong := graph.StrongComponents(g)
 
-	//we'll need to pass a state array of booleans to graph.DFS
-	//make an array of booleans, one element for each vertex
-	state := make([]bool, g.NumVertices())
-
-	//we'll loop through all components - size of each will be saved here
-	current_component_size := 0
-
-	//loop through the state boolean array. We get index of each vertex as 'vertex' and
-	//'visited' is the boolean if it's visited or not
-	for vertex, visited := range state {
-		//if not visited - a new component we have't traversed
-		if visited == false {
-
-			number_of_components++
-			current_component_size = 0
-
-			graph.DFS(g, vertex, state, func(w int) {
-				//increase the size each 'traverse-loop'
-				current_component_size++
-				if current_component_size > largest_component_size {
-					largest_component_size = current_component_size
-				}
-
-			})
-
-		}
+	number_of_weak = weak.Count()
+	number_of_strong = strong.Count()
 
+	//Largest returns -1 if the graph has no vertices
+	if number_of_weak > 0 {
+		largest_weak_size = weak.Size(weak.Largest())
+		largest_strong_size = strong.Size(strong.Largest())
 	}
 
-	//now we have calculated largest_component size and number_of_components and are ready to return
-
 	return
 
 }
@@ -123,10 +102,10 @@ func setupGraphs(n int) (hash_graph_to_return Grapher, matrix_graph_to_return Gr
 
 /*
 	This function runs deep-first-search for each component.
-	Takes in Grapher object.
+	Takes in any graph that DFS can traverse - a Grapher or a frozen graph.CSR.
 */
 
-func runDFS(g Grapher) {
+func runDFS(g graph.Iterator) {
 
 	//boolean array indicating if a vertex has been visited by DFS or not
 	//used to detect new components
@@ -159,22 +138,27 @@ func analyzeGraphInfo(n int) {
 	hashGraph, matrixGraph := setupGraphs(n)
 
 	//get graph info
-	largest_component_size_hash, number_of_components_hash := getLargestSizeAndNumOfComponents(hashGraph)
-	largest_component_size_matrix, number_of_components_matrix := getLargestSizeAndNumOfComponents(matrixGraph)
+	largest_weak_hash, number_of_weak_hash, largest_strong_hash, number_of_strong_hash := getLargestSizeAndNumOfComponents(hashGraph)
+	largest_weak_matrix, number_of_weak_matrix, largest_strong_matrix, number_of_strong_matrix := getLargestSizeAndNumOfComponents(matrixGraph)
 
 	//print graph info
-	fmt.Println("Largest component size in Hash: ", largest_component_size_hash)
-	fmt.Println("Number of components in Hash ", number_of_components_hash)
+	fmt.Println("Largest weak component size in Hash: ", largest_weak_hash)
+	fmt.Println("Number of weak components in Hash ", number_of_weak_hash)
+	fmt.Println("Largest strong component size in Hash: ", largest_strong_hash)
+	fmt.Println("Number of strong components in Hash ", number_of_strong_hash)
 	fmt.Println("------------------")
-	fmt.Println("Largest component size in Matrix: ", largest_component_size_matrix)
-	fmt.Println("Number of components in Matrix ", number_of_components_matrix)
+	fmt.Println("Largest weak component size in Matrix: ", largest_weak_matrix)
+	fmt.Println("Number of weak components in Matrix ", number_of_weak_matrix)
+	fmt.Println("Largest strong component size in Matrix: ", largest_strong_matrix)
+	fmt.Println("Number of strong components in Matrix ", number_of_strong_matrix)
 }
 
 /*
 	This function takes in a slice of ints that holds the graph sizes
 	we will analyze the performance of.
 
-	The function will print the time it took to run DFS 100 times for a hash and matrix graph.
+	The function will print the time it took to run DFS 100 times for a hash and matrix graph,
+	and for a CSR graph frozen from the hash graph.
 */
 
 func analyzeGraphPerformance(graph_sizes []int) {
@@ -212,6 +196,20 @@ func analyzeGraphPerformance(graph_sizes []int) {
 
 		fmt.Println("TIME FOR MATRIX: ", time_taken_matrix)
 
+		//ANALYZE CSR
+		//freezing is done once and not part of the measured time
+		csrGraph := graph.Freeze(hashGraph)
+
+		before_csr := time.Now()
+
+		for i := 0; i < TEST_ITERATIONS; i++ {
+			runDFS(csrGraph)
+		}
+
+		time_taken_csr := time.Since(before_csr)
+
+		fmt.Println("TIME FOR CSR: ", time_taken_csr)
+
 		fmt.Println("------------------")
 	}
 